@@ -0,0 +1,113 @@
+// Package body implements decoding of request bodies.
+// It is used by the generated handlers for binding of action
+// parameters that are marked with "//@body" comment, e.g.:
+//
+//	// Create is an action that expects a JSON or XML body.
+//	//@post /users
+//	//@body input
+//	func (c *Users) Create(input UserForm) http.Handler {
+//		...
+//	}
+package body
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxSize is the maximum size of a request body in bytes.
+// Requests with larger bodies are rejected with 413 status.
+// It may be changed by the application, e.g. in its Init function.
+var MaxSize int64 = 10 << 20
+
+// ErrEmpty is returned if the request has no body.
+var ErrEmpty = errors.New("request body is empty")
+
+// Error is an error of decoding of a request body. It implements
+// http.Handler interface, so it may be returned from actions.
+type Error struct {
+	Status int   // HTTP status code of the response, e.g. 400.
+	Err    error // The original error.
+}
+
+// Error returns a description of the error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// ServeHTTP writes the status code and the description of the error.
+func (e *Error) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, fmt.Sprintf("%s: %v", http.StatusText(e.Status), e.Err), e.Status)
+}
+
+// Handler returns a handler that writes the error. If the error is
+// not an *Error, it is treated as a malformed body, i.e. 400 status is used.
+func Handler(err error) http.Handler {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Status: http.StatusBadRequest, Err: err}
+}
+
+// Decode reads the body of the request and decodes it into v.
+// JSON and XML formats are supported, the format is chosen depending on
+// the Content-Type header. If the header is missing, JSON is expected.
+// An *Error is returned if the body cannot be decoded.
+func Decode(r *http.Request, v interface{}) error {
+	unmarshal, err := decoder(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+	if r.Body == nil {
+		return &Error{Status: http.StatusBadRequest, Err: ErrEmpty}
+	}
+
+	// Read one byte more than allowed to find out
+	// whether the body is too large.
+	d, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxSize+1))
+	if err != nil {
+		return &Error{Status: http.StatusBadRequest, Err: err}
+	}
+	if int64(len(d)) > MaxSize {
+		return &Error{
+			Status: http.StatusRequestEntityTooLarge,
+			Err:    fmt.Errorf("request body exceeds %d bytes", MaxSize),
+		}
+	}
+	if len(d) == 0 {
+		return &Error{Status: http.StatusBadRequest, Err: ErrEmpty}
+	}
+	if err := unmarshal(d, v); err != nil {
+		return &Error{Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+// decoder returns a function for decoding of the body
+// of the requested content type.
+func decoder(contentType string) (func([]byte, interface{}) error, error) {
+	if contentType == "" {
+		return json.Unmarshal, nil
+	}
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, &Error{Status: http.StatusBadRequest, Err: err}
+	}
+	switch {
+	case t == "application/json" || strings.HasSuffix(t, "+json"):
+		return json.Unmarshal, nil
+	case t == "application/xml" || t == "text/xml" || strings.HasSuffix(t, "+xml"):
+		return xml.Unmarshal, nil
+	}
+	return nil, &Error{
+		Status: http.StatusUnsupportedMediaType,
+		Err:    fmt.Errorf(`content type "%s" is not supported`, t),
+	}
+}
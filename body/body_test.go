@@ -0,0 +1,71 @@
+package body
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type user struct {
+	Name string `json:"name" xml:"name"`
+	Age  int    `json:"age" xml:"age"`
+}
+
+func TestDecode(t *testing.T) {
+	for i, v := range []struct {
+		contentType, body string
+		status            int
+	}{
+		{"", `{"name": "John", "age": 42}`, 0},
+		{"application/json; charset=utf-8", `{"name": "John", "age": 42}`, 0},
+		{"application/vnd.api+json", `{"name": "John", "age": 42}`, 0},
+		{"application/xml", `<user><name>John</name><age>42</age></user>`, 0},
+		{"text/xml", `<user><name>John</name><age>42</age></user>`, 0},
+		{"application/json", `{"name": "John",`, http.StatusBadRequest},
+		{"application/json", `{"age": "x"}`, http.StatusBadRequest},
+		{"application/json", ``, http.StatusBadRequest},
+		{"application/xml", `<user>`, http.StatusBadRequest},
+		{"text/plain", `John`, http.StatusUnsupportedMediaType},
+		{"application/json; x", `{}`, http.StatusBadRequest},
+	} {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(v.body))
+		r.Header.Set("Content-Type", v.contentType)
+		u := user{}
+		err := Decode(r, &u)
+		if v.status == 0 {
+			if err != nil || u.Name != "John" || u.Age != 42 {
+				t.Errorf(`Test %d: incorrect result %#v, error: %v.`, i, u, err)
+			}
+			continue
+		}
+		if e, ok := err.(*Error); !ok || e.Status != v.status {
+			t.Errorf(`Test %d: expected error with status %d, got %#v.`, i, v.status, err)
+		}
+	}
+}
+
+func TestDecode_MaxSize(t *testing.T) {
+	defer func(n int64) { MaxSize = n }(MaxSize)
+	MaxSize = 10
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "John"}`))
+	if e, ok := Decode(r, &user{}).(*Error); !ok || e.Status != http.StatusRequestEntityTooLarge {
+		t.Errorf(`Bodies larger than MaxSize must be rejected, got %#v.`, e)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(errors.New("test")).ServeHTTP(w, httptest.NewRequest("POST", "/", nil))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "test") {
+		t.Errorf(`Unexpected response: %d "%s".`, w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	Handler(&Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("test")}).ServeHTTP(w, nil)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf(`Status of the error is expected to be used, got %d.`, w.Code)
+	}
+}
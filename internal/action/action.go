@@ -5,7 +5,9 @@
 package action
 
 import (
+	"fmt"
 	"go/ast"
+	"net/textproto"
 	"strings"
 
 	"github.com/goaltools/goal/internal/log"
@@ -29,10 +31,90 @@ const (
 	// MethodAfter is a name of the magic method that will be executed
 	// after every action.
 	MethodAfter = "After"
+
+	// MethodOnError is a name of the magic method that will be executed
+	// if an action returns a non-nil error. It expects the error
+	// as its only parameter.
+	MethodOnError = "OnError"
+
+	// MethodRecover is a name of the magic method that will be executed
+	// if an action or a magic method panics. It expects the recovered
+	// value as its only parameter.
+	MethodRecover = "Recover"
+
+	// MethodFinally is a name of the magic method that will be executed
+	// at the very end of every request, even if the action panics.
+	// It has neither parameters nor results.
+	MethodFinally = "Finally"
+
+	// MethodReset is a name of the magic method that will be executed
+	// before a controller is put back to the pool to be reused, see
+	// "generate handlers --pool". It has neither parameters nor results.
+	MethodReset = "Reset"
+
+	// ContextImport is an import path of the package of context.Context
+	// type. Actions and magic methods may expect a context as their first
+	// parameter, the request's context is passed to them.
+	ContextImport = "context"
+
+	// DirectiveBody is a name of the comment directive that marks
+	// a parameter of the action as a request body, e.g.:
+	//	//@body input
+	DirectiveBody = "body"
+
+	// DirectiveParam is a name of the comment directive that defines
+	// where a parameter is read from, e.g.:
+	//	//@param token header:X-Auth-Token
+	// Supported sources are "query", "form" (i.e. body of the request),
+	// "path", "header", and "cookie". A key may follow the source
+	// after a colon, by default the name of the parameter is used.
+	// Parameters of the path are set by routers, see strconv.WithPath.
+	// Parameters without the directive are read from the request's Form.
+	DirectiveParam = "param"
+
+	// DirectiveUse is a name of the comment directive that wraps handlers
+	// of an action, or of all actions of a controller, with a middleware,
+	// i.e. a function of "func(http.Handler) http.Handler" type, e.g.:
+	//	//@use gzip.Handler
+	// Functions of the controllers' package are referred to by their names.
+	DirectiveUse = "use"
+
+	// DirectiveSkip is a name of the comment directive that omits magic
+	// Before and After methods of a parent controller (along with its own parents)
+	// or all magic methods of the kind when an action is executed, e.g.:
+	//	//@skip sessions.Sessions
+	//	//@skip Before
+	// Local parent controllers are referred to by their names.
+	DirectiveSkip = "skip"
 )
 
+// Skip represents magic methods an action omits, see DirectiveSkip.
+type Skip struct {
+	Before, After bool     // All magic methods of the kind are omitted.
+	Parents       []string // Omitted controllers, e.g. "github.com/user/app/controllers.Controller".
+}
+
+// Middleware represents a function that wraps handlers of actions.
+type Middleware struct {
+	Import string // Import path of the function's package or "" if it is local.
+	Name   string // Name of the function, e.g. "Handler".
+}
+
+// sources are code fragments that return url.Values of
+// the supported by DirectiveParam sources.
+// Parameters of the path are requested by their keys, see strconv.Path.
+var sources = map[string]string{
+	"query":  "r.URL.Query()",
+	"form":   "r.PostForm",
+	"path":   "strconv.Path(r, %q)",
+	"header": "strconv.Header(r)",
+	"cookie": "strconv.Cookies(r)",
+}
+
 // StrconvContext is a mapping of supported by strconv types and reflect functions.
-var StrconvContext = strconv.Context()
+// StrconvErr is not nil if the mapping cannot be loaded, e.g. because
+// the strconv package is not found.
+var StrconvContext, StrconvErr = strconv.Load()
 
 // Func returns a function that may be used to check whether
 // specific Func represents an action (or one of magic method) or not.
@@ -68,6 +150,20 @@ func Func(pkg *reflect.Package) func(f *reflect.Func) bool {
 			return false
 		}
 
+		// Magic Finally method has no results and may
+		// expect nothing but a context. Methods of the same name
+		// with other signatures are checked as usual actions.
+		if Finally(f) && (len(f.Params) == 0 || Context(pkg, f)) {
+			return true
+		}
+
+		// Magic Reset method has neither parameters nor results.
+		// Methods of the same name with other signatures
+		// are checked as usual actions.
+		if Reset(f) {
+			return true
+		}
+
 		// Check whether we already know from previous iterations
 		// how action subpackage is imported (its name).
 		if _, ok := actionImportName[f.File]; !ok {
@@ -101,19 +197,92 @@ func Func(pkg *reflect.Package) func(f *reflect.Func) bool {
 			return false
 		}
 
-		// Check whether only builtin types are among input parameters.
-		return builtin(f)
+		// Magic OnError and Recover methods expect the error and the recovered value
+		// rather than parameters bound from the request.
+		switch f.Name {
+		case MethodOnError:
+			return magic(f, OnError(f) && (len(f.Params) == 1 || Context(pkg, f)), "error")
+		case MethodRecover:
+			return magic(f, Recover(f) && (len(f.Params) == 1 || Context(pkg, f)), "interface{}")
+		}
+
+		// Check whether only supported types are among input parameters.
+		return supported(pkg, f)
+	}
+}
+
+// magic prints a warning message if the function is not a correct
+// magic method whose only parameter (except a context) is of the requested type
+// and whose only result is of action.Interface type.
+// The ok argument is returned as is.
+func magic(f *reflect.Func, ok bool, typ string) bool {
+	if !ok {
+		log.Warn.Printf(
+			`Method "%s" in file "%s" cannot be treated as a magic method: it must expect a parameter of %s type and, optionally, a context before it, and return nothing but (%s).%s.`,
+			f.Name, f.File, typ, InterfaceImport, Interface,
+		)
 	}
+	return ok
 }
 
-// builtin gets a function and makes sure its arguments are of builtin type.
+// supported gets a function and makes sure its arguments are of builtin type,
+// named types that can be bound, or local structures consisting of such types. A parameter that is marked
+// as a request body must be a local structure, the first parameter may be a context.
+// Validation rules and sources of the parameters must be correct.
 // If not, it prints a warning message and returns false.
-func builtin(f *reflect.Func) bool {
+func supported(pkg *reflect.Package, f *reflect.Func) bool {
+	body, err := Body(f)
+	if err == nil && body != nil {
+		if _, ok := pkg.Struct(body.Type); !ok && !typeParam(f, body.Type) {
+			err = fmt.Errorf(`request body "%s" is of type "%s" that is not a local structure`, body.Name, body.Type)
+		}
+	}
+	if err != nil {
+		log.Warn.Printf(`Method "%s" in file "%s" cannot be treated as action: %v.`, f.Name, f.File, err)
+		return false
+	}
+
+	for _, d := range f.Comments.Directives(strconv.DirectiveValidate) {
+		if len(d) < 2 || f.Params.Filter(func(a *reflect.Arg) bool { return a.Name == d[0] }) == nil {
+			log.Warn.Printf(
+				`Method "%s" in file "%s" cannot be treated as action: "//@%s %s" must refer to a parameter and have rules.`,
+				f.Name, f.File, strconv.DirectiveValidate, strings.Join(d, " "),
+			)
+			return false
+		}
+	}
+
+	for _, d := range f.Comments.Directives(DirectiveParam) {
+		if err := param(pkg, f, body, d); err != nil {
+			log.Warn.Printf(`Method "%s" in file "%s" cannot be treated as action: %v.`, f.Name, f.File, err)
+			return false
+		}
+	}
+
+	if _, err := Uses(pkg, f.File, f.Comments); err != nil {
+		log.Warn.Printf(`Method "%s" in file "%s" cannot be treated as action: %v.`, f.Name, f.File, err)
+		return false
+	}
+
+	if _, err := Skips(pkg, "", f); err != nil {
+		log.Warn.Printf(`Method "%s" in file "%s" cannot be treated as action: %v.`, f.Name, f.File, err)
+		return false
+	}
+
+	b := strconv.Binder{FnMap: StrconvContext, Pkg: pkg, Load: strconv.LoadPackage}.For(f)
+	ctx := Context(pkg, f)
 	fn := func(a *reflect.Arg) bool {
-		if _, ok := StrconvContext[a.Type.String()]; !ok {
+		if body != nil && a.Name == body.Name || ctx && a.Name == f.Params[0].Name || typeParam(f, a.Type) {
+			return true
+		}
+		err := b.Supported(*a)
+		if err == nil {
+			_, err = b.Checks("validation", "r.Form", *a)
+		}
+		if err != nil {
 			log.Warn.Printf(
-				`Method "%s" in file "%s" cannot be treated as action because argument "%s" is of unsupported type "%s".`,
-				f.Name, f.File, a.Name, a.Type,
+				`Method "%s" in file "%s" cannot be treated as action: %v.`,
+				f.Name, f.File, err,
 			)
 			return false
 		}
@@ -122,6 +291,210 @@ func builtin(f *reflect.Func) bool {
 	return len(f.Params.Filter(fn)) == len(f.Params)
 }
 
+// typeParam checks whether the type is a type parameter of the method's
+// receiver, e.g. "T" or "[]T" in case of "func (c *Resource[T]) Create(item T)".
+// Such parameters are checked when the generic controller is instantiated.
+func typeParam(f *reflect.Func, t *reflect.Type) bool {
+	if t == nil || t.Package != "" {
+		return false
+	}
+	for _, p := range f.TypeParams() {
+		if t.Base() == p {
+			return true
+		}
+	}
+	return false
+}
+
+// param checks whether the "//@param" comment with the requested arguments
+// refers to a parameter of the function that can be read from its source.
+// Request bodies cannot, structures cannot be read using custom keys.
+func param(pkg *reflect.Package, f *reflect.Func, body *reflect.Arg, d []string) error {
+	if len(d) != 2 {
+		return fmt.Errorf(`"//@%s %s" must be in "//@%s name source" format`, DirectiveParam, strings.Join(d, " "), DirectiveParam)
+	}
+	as := f.Params.Filter(func(a *reflect.Arg) bool { return a.Name == d[0] })
+	if len(as) == 0 {
+		return fmt.Errorf(`"//@%s %s" must refer to a parameter`, DirectiveParam, strings.Join(d, " "))
+	}
+	if body != nil && body.Name == d[0] {
+		return fmt.Errorf(`request body "%s" cannot be read from another source`, body.Name)
+	}
+	_, key, err := source(f, d[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := pkg.Struct(as[0].Type); ok && key != "" {
+		return fmt.Errorf(`structure "%s" cannot be read using "%s" key`, d[0], key)
+	}
+	return nil
+}
+
+// Source returns code that gets url.Values the parameter of the function
+// must be bound from, see DirectiveParam. If the parameter is read using a custom key,
+// the values are renamed after the parameter, e.g.:
+//	strconv.Rename(strconv.Header(r), "X-Auth-Token", "token")
+// Parameters without the directive are bound from "r.Form".
+// An error is returned if the directive is incorrect.
+func Source(f *reflect.Func, name string) (string, error) {
+	vs, _, err := source(f, name)
+	return vs, err
+}
+
+// source is an implementation of Source that also returns a custom key
+// the parameter is read using. If the key is equal to the name
+// of the parameter, empty string is returned instead.
+func source(f *reflect.Func, name string) (vs, key string, err error) {
+	vs = "r.Form"
+	found := false
+	for _, d := range f.Comments.Directives(DirectiveParam) {
+		if len(d) != 2 {
+			return "", "", fmt.Errorf(`"//@%s %s" must be in "//@%s name source" format`, DirectiveParam, strings.Join(d, " "), DirectiveParam)
+		}
+		if d[0] != name {
+			continue
+		}
+		if found {
+			return "", "", fmt.Errorf(`source of parameter "%s" is defined more than once`, name)
+		}
+		found = true
+
+		src, k := d[1], name
+		if i := strings.Index(src, ":"); i >= 0 && i < len(src)-1 {
+			src, k = src[:i], src[i+1:]
+		}
+		v, ok := sources[src]
+		if !ok {
+			return "", "", fmt.Errorf(`parameter "%s" has unknown source "%s"`, name, d[1])
+		}
+		switch src {
+		case "header":
+			k = textproto.CanonicalMIMEHeaderKey(k)
+		case "path":
+			v = fmt.Sprintf(v, k)
+		}
+		vs = v
+		if k != name {
+			key = k
+			vs = fmt.Sprintf(`strconv.Rename(%s, %q, %q)`, v, k, name)
+		}
+	}
+	return
+}
+
+// Uses returns middleware that are defined by DirectiveUse comments
+// of a function or a structure declared in the requested file of the package.
+// An error is returned if a middleware cannot be found or
+// it is not of "func(http.Handler) http.Handler" type.
+func Uses(pkg *reflect.Package, file string, cs reflect.Comments) (ms []Middleware, err error) {
+	for _, d := range cs.Directives(DirectiveUse) {
+		if len(d) != 1 {
+			return nil, fmt.Errorf(`"//@%s %s" must be in "//@%s pkg.Func" format`, DirectiveUse, strings.Join(d, " "), DirectiveUse)
+		}
+		m, p := Middleware{Name: d[0]}, pkg
+		if i := strings.LastIndex(d[0], "."); i >= 0 {
+			imp, ok := pkg.Imports.Value(file, d[0][:i])
+			if !ok {
+				return nil, fmt.Errorf(`package of middleware "%s" is not imported`, d[0])
+			}
+			if p, ok = strconv.LoadPackage(imp); !ok {
+				return nil, fmt.Errorf(`package "%s" of middleware "%s" cannot be found`, imp, d[0])
+			}
+			m.Import, m.Name = imp, d[0][i+1:]
+		}
+		if !middleware(p, m.Name) {
+			return nil, fmt.Errorf(`middleware "%s" must be a function of "func(http.Handler) http.Handler" type`, d[0])
+		}
+		ms = append(ms, m)
+	}
+	return
+}
+
+// Skips returns magic methods the function omits as defined by its
+// DirectiveSkip comments. Controllers are identified by import paths
+// of their packages and names, the requested import path is used
+// for the local ones. An error is returned if a comment is incorrect
+// or a package of the controller is not imported.
+func Skips(pkg *reflect.Package, imp string, f *reflect.Func) (s Skip, err error) {
+	for _, d := range f.Comments.Directives(DirectiveSkip) {
+		if len(d) != 1 {
+			return Skip{}, fmt.Errorf(`"//@%s %s" must be in "//@%s pkg.Controller" format`, DirectiveSkip, strings.Join(d, " "), DirectiveSkip)
+		}
+		switch name := d[0]; {
+		case name == MethodBefore:
+			s.Before = true
+		case name == MethodAfter:
+			s.After = true
+		case strings.Contains(name, "."):
+			i := strings.LastIndex(name, ".")
+			p, ok := pkg.Imports.Value(f.File, name[:i])
+			if !ok {
+				return Skip{}, fmt.Errorf(`package of controller "%s" is not imported`, name)
+			}
+			s.Parents = append(s.Parents, p+name[i:])
+		default:
+			s.Parents = append(s.Parents, imp+"."+name)
+		}
+	}
+	return
+}
+
+// middleware checks whether the package has a function with the requested
+// name that is of "func(http.Handler) http.Handler" type.
+func middleware(pkg *reflect.Package, name string) bool {
+	for _, f := range pkg.Funcs {
+		if f.Name != name || f.Recv != nil || len(f.Params) != 1 || len(f.Results) != 1 {
+			continue
+		}
+		return handler(pkg, f.File, f.Params[0].Type) && handler(pkg, f.File, f.Results[0].Type)
+	}
+	return false
+}
+
+// handler checks whether the type declared in the file
+// of the package is http.Handler.
+func handler(pkg *reflect.Package, file string, t *reflect.Type) bool {
+	if t == nil || t.Star || t.Name != Interface || t.Package == "" {
+		return false
+	}
+	imp, ok := pkg.Imports.Value(file, t.Package)
+	return ok && imp == InterfaceImport
+}
+
+// Body returns a parameter of the function that is marked as a request body
+// using the "//@body name" comment. If there is no such comment, nil is returned.
+// An error is returned if the comment is incorrect or refers to an unknown parameter.
+func Body(f *reflect.Func) (*reflect.Arg, error) {
+	ds := f.Comments.Directives(DirectiveBody)
+	switch {
+	case len(ds) == 0:
+		return nil, nil
+	case len(ds) > 1 || len(ds[0]) != 1:
+		return nil, fmt.Errorf(`exactly one "//@%s name" comment is expected`, DirectiveBody)
+	}
+	for i := range f.Params {
+		if f.Params[i].Name == ds[0][0] {
+			return &f.Params[i], nil
+		}
+	}
+	return nil, fmt.Errorf(`request body "%s" is not a parameter`, ds[0][0])
+}
+
+// Context checks whether the first parameter of the function is of
+// context.Context type. Such parameters are not bound from the form,
+// the request's context is passed instead.
+func Context(pkg *reflect.Package, f *reflect.Func) bool {
+	if len(f.Params) == 0 {
+		return false
+	}
+	t := f.Params[0].Type
+	if t == nil || t.Star || t.Name != "Context" || t.Package == "" {
+		return false
+	}
+	imp, ok := pkg.Imports.Value(f.File, t.Package)
+	return ok && imp == ContextImport
+}
+
 // Before gets an action Func and checks whether it is a Before magic action.
 func Before(f *reflect.Func) bool {
 	if f.Name == MethodBefore {
@@ -138,9 +511,48 @@ func After(f *reflect.Func) bool {
 	return false
 }
 
+// OnError gets an action Func and checks whether it is an OnError magic method,
+// i.e. its last parameter is of error type and it has a single result.
+// A context may precede the error, see Context.
+func OnError(f *reflect.Func) bool {
+	n := len(f.Params)
+	return f.Name == MethodOnError && (n == 1 || n == 2) && len(f.Results) == 1 &&
+		f.Params[n-1].Type.String() == "error"
+}
+
+// Recover gets an action Func and checks whether it is a Recover magic method,
+// i.e. its last parameter is of interface{} type and it has a single result.
+// A context may precede the recovered value, see Context.
+func Recover(f *reflect.Func) bool {
+	n := len(f.Params)
+	if f.Name != MethodRecover || n != 1 && n != 2 || len(f.Results) != 1 {
+		return false
+	}
+	t := f.Params[n-1].Type.String()
+	return t == "interface{}" || t == "any"
+}
+
+// Finally gets a Func and checks whether it is a Finally magic method,
+// i.e. it has no results and expects nothing but, optionally, a context.
+func Finally(f *reflect.Func) bool {
+	return f.Name == MethodFinally && len(f.Params) <= 1 && len(f.Results) == 0
+}
+
+// Reset gets a Func and checks whether it is a Reset magic method,
+// i.e. it has neither parameters nor results.
+func Reset(f *reflect.Func) bool {
+	return f.Name == MethodReset && len(f.Params) == 0 && len(f.Results) == 0
+}
+
+// ReturnsError checks whether the last of the action's results
+// (except the first one) is of error type.
+func ReturnsError(f *reflect.Func) bool {
+	return len(f.Results) > 1 && f.Results[len(f.Results)-1].Type.String() == "error"
+}
+
 // Regular gets an action Func and makes sure it is not a magic action but a usual one.
 func Regular(f *reflect.Func) bool {
-	if Before(f) || After(f) {
+	if Before(f) || After(f) || f.Name == MethodOnError || f.Name == MethodRecover || Finally(f) || Reset(f) {
 		return false
 	}
 	return true
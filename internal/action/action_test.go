@@ -1,6 +1,7 @@
 package action
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/goaltools/goal/internal/reflect"
@@ -64,7 +65,7 @@ func TestFunc(t *testing.T) {
 	}
 }
 
-func TestBuiltin(t *testing.T) {
+func TestSupported(t *testing.T) {
 	f := &reflect.Func{
 		Name: "Test",
 		Params: []reflect.Arg{
@@ -76,7 +77,7 @@ func TestBuiltin(t *testing.T) {
 			},
 		},
 	}
-	if builtin(f) != true {
+	if supported(&reflect.Package{}, f) != true {
 		t.Errorf("Parameters of %#v are builtin. True expected, got false.", f)
 	}
 
@@ -95,9 +96,87 @@ func TestBuiltin(t *testing.T) {
 			Type: &reflect.Type{},
 		},
 	}
-	if builtin(f) != false {
+	if supported(&reflect.Package{}, f) != false {
 		t.Errorf("Parameter `test.Test` of %#v is not builtin. False expected, got true.", f)
 	}
+
+	f = &reflect.Func{
+		Name:   "Test",
+		Params: []reflect.Arg{{Name: "page", Type: &reflect.Type{Name: "int"}}},
+	}
+	for cs, exp := range map[string]bool{
+		"//@validate page min=1 max=100": true,
+		"//@validate page pattern=.*":    false,
+		"//@validate page":               false,
+		"//@validate unknown required":   false,
+	} {
+		f.Comments = []string{cs}
+		if res := supported(&reflect.Package{}, f); res != exp {
+			t.Errorf(`"%s": expected %v, got %v.`, cs, exp, res)
+		}
+	}
+}
+
+func TestBody(t *testing.T) {
+	f := &reflect.Func{
+		Name: "Create",
+		Params: []reflect.Arg{
+			{Name: "page", Type: &reflect.Type{Name: "int"}},
+			{Name: "input", Type: &reflect.Type{Name: "User"}},
+		},
+	}
+	if a, err := Body(f); a != nil || err != nil {
+		t.Errorf("No body expected, got %#v, %v.", a, err)
+	}
+
+	f.Comments = []string{"//@post /users", "//@body   input"}
+	if a, err := Body(f); err != nil || a == nil || a.Name != "input" {
+		t.Errorf(`Parameter "input" expected, got %#v, %v.`, a, err)
+	}
+	pkg := &reflect.Package{Structs: []reflect.Struct{{Name: "User"}}}
+	if !supported(pkg, f) {
+		t.Errorf("Request body of local structure type must be supported.")
+	}
+	if supported(&reflect.Package{}, f) {
+		t.Errorf("Request body of unknown type must not be supported.")
+	}
+
+	for _, cs := range [][]string{
+		{"//@body"},
+		{"//@body unknown"},
+		{"//@body input", "//@body page"},
+	} {
+		f.Comments = cs
+		if _, err := Body(f); err == nil {
+			t.Errorf("%v: error expected.", cs)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	pkg := &reflect.Package{
+		Imports: reflect.Imports{
+			"app.go": {"ctx": "context", "http": "net/http"},
+		},
+	}
+	f := *actionFn
+	f.File = "app.go"
+	if Context(pkg, &f) {
+		t.Errorf("Incorrect result: the first parameter is not a context.")
+	}
+
+	f.Params = []reflect.Arg{
+		{Name: "c", Type: &reflect.Type{Name: "Context", Package: "ctx"}},
+		{Name: "page", Type: &reflect.Type{Name: "int"}},
+	}
+	if !Context(pkg, &f) || !supported(pkg, &f) {
+		t.Errorf("Incorrect result: the first parameter is a context.")
+	}
+
+	f.Params[0].Type.Package = "http"
+	if Context(pkg, &f) {
+		t.Errorf("Incorrect result: context must be imported from %s package.", ContextImport)
+	}
 }
 
 func TestBefore(t *testing.T) {
@@ -128,6 +207,119 @@ func TestAfter(t *testing.T) {
 	}
 }
 
+func TestOnError(t *testing.T) {
+	f := *actionFn
+	f.Name = "OnError"
+	if OnError(&f) {
+		t.Errorf("Incorrect result: OnError must expect an error.")
+	}
+
+	f.Params = []reflect.Arg{{Name: "err", Type: &reflect.Type{Name: "error"}}}
+	if !OnError(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: action is a magic OnError method.")
+	}
+
+	f.Results = append(f.Results, reflect.Arg{Type: &reflect.Type{Name: "error"}})
+	if OnError(&f) {
+		t.Errorf("Incorrect result: OnError must return nothing but a handler.")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	f := *actionFn
+	f.Name = "Recover"
+	if Recover(&f) {
+		t.Errorf("Incorrect result: Recover must expect a recovered value.")
+	}
+
+	f.Params = []reflect.Arg{{Name: "v", Type: &reflect.Type{Name: "interface{}"}}}
+	if !Recover(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: action is a magic Recover method.")
+	}
+
+	f.Results = append(f.Results, reflect.Arg{Type: &reflect.Type{Name: "error"}})
+	if Recover(&f) {
+		t.Errorf("Incorrect result: Recover must return nothing but a handler.")
+	}
+}
+
+func TestFinally(t *testing.T) {
+	f := *actionFn
+	f.Name = "Finally"
+	if Finally(&f) {
+		t.Errorf("Incorrect result: Finally must have neither parameters nor results.")
+	}
+
+	f.Params, f.Results = nil, nil
+	if !Finally(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: method is a magic Finally method.")
+	}
+}
+
+func TestReset(t *testing.T) {
+	f := reflect.Func{
+		Name:   "Reset",
+		Params: []reflect.Arg{{Name: "page", Type: &reflect.Type{Name: "int"}}},
+	}
+	if Reset(&f) {
+		t.Errorf("Incorrect result: Reset must have neither parameters nor results.")
+	}
+
+	f.Params = nil
+	if !Reset(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: method is a magic Reset method.")
+	}
+}
+
+func TestFunc_MagicNames(t *testing.T) {
+	fn := Func(&reflect.Package{
+		Imports: reflect.Imports{
+			"app.go": map[string]string{
+				"http": "net/http",
+			},
+		},
+	})
+	f := reflect.Func{
+		Name:    "Finally",
+		File:    "app.go",
+		Recv:    &reflect.Arg{Name: "c", Type: &reflect.Type{Name: "App", Star: true}},
+		Params:  []reflect.Arg{{Name: "page", Type: &reflect.Type{Name: "int"}}},
+		Results: []reflect.Arg{{Type: &reflect.Type{Name: "Handler", Package: "http"}}},
+	}
+	if !fn(&f) || !Regular(&f) {
+		t.Errorf("Methods named as magic ones but having signatures of actions are expected to be actions.")
+	}
+
+	f.Params, f.Results = nil, nil
+	if !fn(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: method is a magic Finally method.")
+	}
+
+	f.Name = "Reset"
+	f.Params = []reflect.Arg{{Name: "page", Type: &reflect.Type{Name: "int"}}}
+	f.Results = []reflect.Arg{{Type: &reflect.Type{Name: "Handler", Package: "http"}}}
+	if !fn(&f) || !Regular(&f) {
+		t.Errorf("Methods named as magic ones but having signatures of actions are expected to be actions.")
+	}
+
+	f.Params, f.Results = nil, nil
+	if !fn(&f) || Regular(&f) {
+		t.Errorf("Incorrect result: method is a magic Reset method.")
+	}
+}
+
+func TestReturnsError(t *testing.T) {
+	f := *actionFn
+	if ReturnsError(&f) {
+		t.Errorf("Incorrect result: action does not return an error.")
+	}
+
+	f.Results = append(f.Results, reflect.Arg{Type: &reflect.Type{Name: "error"}})
+	if !ReturnsError(&f) {
+		t.Errorf("Incorrect result: action returns an error.")
+	}
+}
+
 func TestRegular(t *testing.T) {
 	f := actionFn
 	f.Name = "Before"
@@ -173,3 +365,152 @@ var actionFn = &reflect.Func{
 		},
 	},
 }
+
+func TestSource(t *testing.T) {
+	f := &reflect.Func{
+		Comments: []string{
+			"//@param token header:x-auth-token",
+			"//@param lang header",
+			"//@param sid cookie:session",
+			"//@param page query",
+			"//@param u form",
+			"//@param id path",
+			"//@param uid path:user_id",
+		},
+		Params: []reflect.Arg{
+			{Name: "token", Type: &reflect.Type{Name: "string"}},
+			{Name: "lang", Type: &reflect.Type{Name: "string"}},
+			{Name: "sid", Type: &reflect.Type{Name: "string"}},
+			{Name: "page", Type: &reflect.Type{Name: "int"}},
+			{Name: "u", Type: &reflect.Type{Name: "User"}},
+			{Name: "id", Type: &reflect.Type{Name: "int"}},
+			{Name: "uid", Type: &reflect.Type{Name: "int"}},
+			{Name: "q", Type: &reflect.Type{Name: "string"}},
+		},
+	}
+	for name, exp := range map[string]string{
+		"token": `strconv.Rename(strconv.Header(r), "X-Auth-Token", "token")`,
+		"lang":  `strconv.Rename(strconv.Header(r), "Lang", "lang")`,
+		"sid":   `strconv.Rename(strconv.Cookies(r), "session", "sid")`,
+		"page":  "r.URL.Query()",
+		"u":     "r.PostForm",
+		"id":    `strconv.Path(r, "id")`,
+		"uid":   `strconv.Rename(strconv.Path(r, "user_id"), "user_id", "uid")`,
+		"q":     "r.Form",
+	} {
+		if vs, err := Source(f, name); err != nil || vs != exp {
+			t.Errorf(`"%s": expected "%s", got "%s", %v.`, name, exp, vs, err)
+		}
+	}
+	pkg := &reflect.Package{Structs: []reflect.Struct{{Name: "User"}}}
+	if !supported(pkg, f) {
+		t.Errorf("Parameters with correct sources must be supported.")
+	}
+
+	for _, c := range []string{
+		"//@param page",
+		"//@param page body",
+		"//@param unknown query",
+		"//@param u query:user",
+	} {
+		f.Comments = []string{c}
+		if supported(pkg, f) {
+			t.Errorf(`"%s": parameters are expected to be unsupported.`, c)
+		}
+	}
+	f.Comments = []string{"//@param page query", "//@param page form"}
+	if _, err := Source(f, "page"); err == nil {
+		t.Errorf("Error expected if the source is defined more than once.")
+	}
+}
+
+func TestUses(t *testing.T) {
+	handler := &reflect.Type{Name: "Handler", Package: "http"}
+	pkg := &reflect.Package{
+		Funcs: reflect.Funcs{
+			{File: "app.go", Name: "Logged", Params: []reflect.Arg{{Type: handler}}, Results: []reflect.Arg{{Type: handler}}},
+			{File: "app.go", Name: "Incorrect", Params: []reflect.Arg{{Type: handler}}},
+		},
+		Imports: reflect.Imports{
+			"app.go": {"http": "net/http", "unknown": "github.com/goaltools/goal/unknown"},
+		},
+	}
+	ms, err := Uses(pkg, "app.go", reflect.Comments{"// Sample comment.", "//@use Logged", "//@use Logged"})
+	if exp := []Middleware{{Name: "Logged"}, {Name: "Logged"}}; err != nil || len(ms) != 2 || ms[0] != exp[0] {
+		t.Errorf("Expected %#v, got %#v, %v.", exp, ms, err)
+	}
+
+	for _, c := range []string{
+		"//@use",
+		"//@use Incorrect",
+		"//@use Unknown",
+		"//@use notimported.Func",
+		"//@use unknown.Func",
+	} {
+		if _, err := Uses(pkg, "app.go", reflect.Comments{c}); err == nil {
+			t.Errorf(`"%s": error expected.`, c)
+		}
+	}
+}
+
+func TestSkips(t *testing.T) {
+	pkg := &reflect.Package{
+		Imports: reflect.Imports{
+			"app.go": {"sessions": "github.com/goaltools/contrib/controllers/sessions"},
+		},
+	}
+	f := &reflect.Func{
+		File: "app.go",
+		Comments: reflect.Comments{
+			"// Sample comment.", "//@skip sessions.Sessions", "//@skip Controller", "//@skip After",
+		},
+	}
+	exp := Skip{
+		After: true,
+		Parents: []string{
+			"github.com/goaltools/contrib/controllers/sessions.Sessions",
+			"github.com/user/app/controllers.Controller",
+		},
+	}
+	if s, err := Skips(pkg, "github.com/user/app/controllers", f); err != nil || fmt.Sprint(s) != fmt.Sprint(exp) {
+		t.Errorf("Expected %#v, got %#v, %v.", exp, s, err)
+	}
+
+	for _, c := range []string{
+		"//@skip",
+		"//@skip Before After",
+		"//@skip notimported.Controller",
+	} {
+		if _, err := Skips(pkg, "", &reflect.Func{File: "app.go", Comments: reflect.Comments{c}}); err == nil {
+			t.Errorf(`"%s": error expected.`, c)
+		}
+	}
+}
+
+func TestFunc_Generic(t *testing.T) {
+	fn := Func(&reflect.Package{
+		Imports: reflect.Imports{
+			"app.go": map[string]string{
+				"http": "net/http",
+			},
+		},
+	})
+	f := reflect.Func{
+		Name:    "Create",
+		File:    "app.go",
+		Recv:    &reflect.Arg{Name: "c", Type: &reflect.Type{Name: "Resource", Star: true, Args: []*reflect.Type{{Name: "T"}}}},
+		Results: []reflect.Arg{{Type: &reflect.Type{Name: "Handler", Package: "http"}}},
+	}
+	f.Params = []reflect.Arg{
+		{Name: "item", Type: &reflect.Type{Name: "T"}},
+		{Name: "items", Type: &reflect.Type{Name: "[]T"}},
+	}
+	if !fn(&f) {
+		t.Errorf("Parameters of type parameter types are expected to be supported.")
+	}
+
+	f.Recv = &reflect.Arg{Name: "c", Type: &reflect.Type{Name: "App", Star: true}}
+	if fn(&f) {
+		t.Errorf(`"T" is not a type parameter of the receiver and thus is not supported.`)
+	}
+}
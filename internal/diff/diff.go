@@ -0,0 +1,209 @@
+// Package diff is used for comparison of text files
+// and printing the differences in unified format.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines that are
+// shown around every group of changes.
+const context = 3
+
+// Kinds of edits.
+const (
+	equal  = ' '
+	insert = '+'
+	remove = '-'
+)
+
+// edit is a single step of transforming one list of lines into another.
+// A and B are indexes of the line in the old and new lists.
+// For insertions A is a position in the old list the line is inserted at,
+// for removals B is a position in the new list.
+type edit struct {
+	kind byte
+	a, b int
+}
+
+// Unified compares the old and new contents and returns their difference
+// in unified format with the requested file names in the header.
+// If the contents are equal, empty string is returned.
+func Unified(oldName, newName string, old, new []byte) string {
+	if bytes.Equal(old, new) {
+		return ""
+	}
+	a, b := lines(old), lines(new)
+	es := edits(a, b)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(es) {
+		hs := es[h[0]:h[1]]
+
+		// Calculate ranges of the hunk.
+		as, bs := 0, 0
+		for _, e := range hs {
+			if e.kind != insert {
+				as++
+			}
+			if e.kind != remove {
+				bs++
+			}
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(hs[0].a, as), hunkRange(hs[0].b, bs))
+
+		for _, e := range hs {
+			l := ""
+			if e.kind == insert {
+				l = b[e.b]
+			} else {
+				l = a[e.a]
+			}
+			buf.WriteByte(e.kind)
+			buf.WriteString(l)
+			if !strings.HasSuffix(l, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return buf.String()
+}
+
+// hunkRange returns a range of lines in "start,count" format.
+// Start is 1-based unless there are no lines in the range.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// hunks groups the edits into hunks. It returns pairs of
+// start and end indexes of the edits of every hunk.
+func hunks(es []edit) (res [][2]int) {
+	for i := 0; i < len(es); i++ {
+		if es[i].kind == equal {
+			continue
+		}
+
+		// Include the unchanged lines before the change.
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+
+		// Find the end of the hunk. Changes that are separated
+		// by less than two contexts are joined into one hunk.
+		end, eq := i, 0
+		for ; end < len(es) && eq <= 2*context; end++ {
+			if es[end].kind == equal {
+				eq++
+				continue
+			}
+			eq = 0
+		}
+		end -= eq
+		if eq > context {
+			end += context
+		} else {
+			end += eq
+		}
+		res = append(res, [2]int{start, end})
+		i = end
+	}
+	return
+}
+
+// edits returns the shortest list of edits that transform a into b.
+// Myers' algorithm is used.
+func edits(a, b []string) []edit {
+	n, m := len(a), len(b)
+
+	// Files are created or removed as a whole.
+	if n == 0 || m == 0 {
+		res := []edit{}
+		for i := range a {
+			res = append(res, edit{remove, i, 0})
+		}
+		for i := range b {
+			res = append(res, edit{insert, 0, i})
+		}
+		return res
+	}
+
+	max := n + m
+	off := max + 1
+	v := make([]int, 2*max+3)
+	trace := [][]int{}
+
+	// Find the shortest path, saving the state before every step.
+loop:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break loop
+			}
+		}
+	}
+
+	// Restore the path going from the end to the beginning.
+	res := []edit{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		pk := k - 1
+		if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+			pk = k + 1
+		}
+		px := v[off+pk]
+		py := px - pk
+		for x > px && y > py {
+			res = append(res, edit{equal, x - 1, y - 1})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == px {
+				res = append(res, edit{insert, x, y - 1})
+			} else {
+				res = append(res, edit{remove, x - 1, y})
+			}
+		}
+		x, y = px, py
+	}
+
+	// The edits were collected in reverse order.
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// lines splits the data into lines, keeping the line breaks.
+func lines(d []byte) []string {
+	if len(d) == 0 {
+		return nil
+	}
+	ls := strings.SplitAfter(string(d), "\n")
+	if ls[len(ls)-1] == "" {
+		ls = ls[:len(ls)-1]
+	}
+	return ls
+}
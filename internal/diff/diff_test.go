@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	for i, v := range []struct {
+		old, new, exp string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{
+			"", "a\nb\n",
+			"--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			"a\nb\n", "",
+			"--- old\n+++ new\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			"a\nb\nc\n", "a\nx\nc\n",
+			"--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			"a\nb", "a\nb\n",
+			"--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			lines10(), strings.Replace(strings.Replace(lines10(), "1\n", "one\n", 1), "9\n", "nine\n", 1),
+			"--- old\n+++ new\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -6,4 +6,4 @@\n 6\n 7\n 8\n-9\n+nine\n",
+		},
+		{
+			lines10(), strings.Replace(strings.Replace(lines10(), "2\n", "two\n", 1), "8\n", "eight\n", 1),
+			"--- old\n+++ new\n" +
+				"@@ -1,9 +1,9 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n",
+		},
+	} {
+		if res := Unified("old", "new", []byte(v.old), []byte(v.new)); res != v.exp {
+			t.Errorf("Test %d: expected:\n%s\ngot:\n%s", i, v.exp, res)
+		}
+	}
+}
+
+func lines10() string {
+	return "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+}
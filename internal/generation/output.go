@@ -4,6 +4,7 @@ package generation
 
 import (
 	"bytes"
+	"fmt"
 	"go/format"
 	"io/ioutil"
 	"os"
@@ -45,50 +46,69 @@ type Type struct {
 // "\" + "\n" sequences are removed from the template so newline
 // elision is supported. Moreover, ":" + "\t" are removed too for
 // a possibility of a better code formatting.
-func NewType(pkg, templatePath string) Type {
+func NewType(pkg, templatePath string) (Type, error) {
 	// Read the template file, cut all "\" + line break.
 	f, err := ioutil.ReadFile(templatePath)
 	if err != nil {
-		log.Error.Panicf(`Cannot open template file "%s". Error: %v.`, templatePath, err)
+		return Type{}, fmt.Errorf(`cannot open template file "%s": %v`, templatePath, err)
 	}
+	return ParseType(pkg, filepath.Base(templatePath), string(f))
+}
 
+// ParseType is similar to NewType but gets a name of the template
+// and its content rather than a path. It is used for the templates
+// that are embedded into the binary.
+func ParseType(pkg, name, content string) (Type, error) {
 	// Allocate a new type, initialize template, then return.
 	// Use <@ and > as delimiters, add template helper functions.
-	n := filepath.Base(templatePath)
-	t, err := template.New(n).Delims("<@", ">").Funcs(funcs).Parse(string(f))
+	t, err := template.New(name).Delims("<@", ">").Funcs(funcs).Parse(content)
 	if err != nil {
-		log.Error.Panicf(`Cannot parse template "%s". Error: %v.`, templatePath, err)
+		return Type{}, fmt.Errorf(`cannot parse template "%s": %v`, name, err)
 	}
 	return Type{
 		Package:      pkg,
-		TemplateName: n,
+		TemplateName: name,
 		Template:     t,
-	}
+	}, nil
 }
 
 // CreateDir initializes output.Type.Path with the requested path
 // and tries to create it in filesystem if it doesn't exist yet.
-// It panics in case of error.
-func (t *Type) CreateDir(path string) {
+func (t *Type) CreateDir(path string) error {
 	t.Path = path
 
 	// Check whether directory already exists.
 	if _, err := os.Stat(path); !os.IsNotExist(err) {
-		return
+		return nil
 	}
 
 	// If not, try to create it.
-	err := os.MkdirAll(path, 0755)
-	if err != nil {
-		log.Error.Panicf("Cannot create directory '%s', error: '%s'.", path, err)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf(`cannot create directory "%s": %v`, path, err)
 	}
+	return nil
 }
 
 // Generate creates a file with a name specified in Type.Package and Type.Extension
 // in the location defined in Type.Path and with the content defined by Type.Template.
 // The output directory should be created in advance. It's possible to do it using:
 //	CreateDir("./path/to/output/")
-func (t *Type) Generate() {
+func (t *Type) Generate() error {
+	data, err := t.Render()
+	if err != nil {
+		return err
+	}
+	return Save(t.File(), data)
+}
+
+// File returns a path of the file that is generated by the Type.
+func (t *Type) File() string {
+	return filepath.Join(t.Path, t.Package+t.Extension)
+}
+
+// Render executes the Template and returns go formatted result
+// without saving it.
+func (t *Type) Render() ([]byte, error) {
 	// Generate a template file.
 	var buffer bytes.Buffer
 	err := t.Template.ExecuteTemplate(&buffer, t.TemplateName, map[string]interface{}{
@@ -98,22 +118,59 @@ func (t *Type) Generate() {
 		"path":      t.Path,
 	})
 	if err != nil {
-		log.Error.Panicf("Didn't manage to execute a template, error: '%s'.", err)
+		return nil, fmt.Errorf(`cannot execute template "%s": %v`, t.TemplateName, err)
 	}
 
-	// Print debugging information.
-	path := filepath.Join(t.Path, t.Package+t.Extension)
-	log.Info.Printf("Saving generated '%s' file to '%s'.", t.Package, path)
-
 	// Go format the result.
 	fmtBuf, err := format.Source(buffer.Bytes())
 	if err != nil {
-		log.Error.Panicf(`Cannot go format generated code. Error: %v.`, err)
+		return nil, fmt.Errorf(`cannot go format code generated for "%s": %v`, t.File(), err)
 	}
+	return fmtBuf, nil
+}
+
+// Save writes the generated data to the requested file.
+// The data is written to a temporary file first that then replaces
+// the requested one, so the file is never left half-written.
+// The directory of the file should be created in advance.
+func Save(path string, data []byte) error {
+	// Print debugging information.
+	log.Info.Printf("Saving generated file to '%s'.", path)
 
 	// Write result to the file.
-	err = ioutil.WriteFile(path, fmtBuf, 0644)
+	tmp, err := Stage(path, data)
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf(`cannot save generated file "%s": %v`, path, err)
+	}
+	return nil
+}
+
+// Stage writes the data to a new temporary file in the directory
+// of the requested path and returns the name of the temporary file.
+// It is expected to be moved to the path using os.Rename.
+// Nothing is left if an error is returned.
+func Stage(path string, data []byte) (string, error) {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
 	if err != nil {
-		log.Error.Panicf("Failed to save generated file, error: '%s'.", err)
+		os.Remove(f.Name())
+		return "", err
 	}
+	return f.Name(), nil
 }
@@ -10,23 +10,22 @@ import (
 )
 
 func TestNewType(t *testing.T) {
-	// We are making sure there are no any panics.
-	typ := NewType("test", "./output.go")
-	if typ.Package != "test" {
-		t.Error("package name was not initialized")
+	typ, err := NewType("test", "./output.go")
+	if err != nil || typ.Package != "test" {
+		t.Errorf("package name was not initialized, error: %v", err)
 	}
 }
 
 func TestNewType_IncorrectPath(t *testing.T) {
-	defer expectPanic("When we are not able to read template file, a panic must occur.")
-	NewType("test", "./pathThatDoesNotExist")
+	if _, err := NewType("test", "./pathThatDoesNotExist"); err == nil {
+		t.Error("When we are not able to read template file, an error must be returned.")
+	}
 }
 
 func TestCreateDir_ExistingDirectory(t *testing.T) {
 	typ := Type{}
-	typ.CreateDir("./testdata")
-	if typ.Path != "./testdata" {
-		t.Error("Type.Path is expected to be initialized.")
+	if err := typ.CreateDir("./testdata"); err != nil || typ.Path != "./testdata" {
+		t.Errorf("Type.Path is expected to be initialized, error: %v.", err)
 	}
 }
 
@@ -35,13 +34,16 @@ func TestCreateDir_NoWritePrivileges(t *testing.T) {
 	os.Chmod("./testdata/readonly", 0544)
 
 	typ := Type{}
-	defer expectPanic("We have no write privileges for './testdata/readonly', so panic expected.")
-	typ.CreateDir("./testdata/readonly/something")
+	if err := typ.CreateDir("./testdata/readonly/something"); err == nil {
+		t.Error("We have no write privileges for './testdata/readonly', so an error expected.")
+	}
 }
 
 func TestCreateDir(t *testing.T) {
 	typ := Type{}
-	typ.CreateDir("./testdata/assets/something")
+	if err := typ.CreateDir("./testdata/assets/something"); err != nil {
+		t.Error(err)
+	}
 
 	// Make sure the directory exists.
 	if _, err := os.Stat("./testdata/assets/something"); err != nil {
@@ -55,29 +57,42 @@ func TestCreateDir(t *testing.T) {
 }
 
 func TestGenerate_IncorrectTemplate(t *testing.T) {
-	typ := NewType("test", "./testdata/incorrect.template")
+	typ, err := NewType("test", "./testdata/incorrect.template")
+	if err != nil {
+		t.Fatal(err)
+	}
 	typ.Path = "./testdata"
-	defer expectPanic("Template has errors and thus an error expected.")
-	typ.Generate()
+	if err := typ.Generate(); err == nil {
+		t.Error("Template has errors and thus an error expected.")
+	}
 }
 
 func TestGenerate_NoWritePrivileges(t *testing.T) {
 	// Prepare a readonly directory.
 	os.Chmod("./testdata/readonly", 0544)
 
-	typ := NewType("test", "./testdata/test.template")
+	typ, err := NewType("test", "./testdata/test.template")
+	if err != nil {
+		t.Fatal(err)
+	}
 	typ.Path = "./testdata/readonly"
-	defer expectPanic("We do not have write access to the directory, thus panic expected.")
-	typ.Generate()
+	if err := typ.Generate(); err == nil {
+		t.Error("We do not have write access to the directory, thus an error expected.")
+	}
 }
 
 func TestGenerate(t *testing.T) {
 	// Generate a new "test" package using "./testdata/test.template" template
 	// and save it to "./testdata/result/test.go".
-	typ := NewType("test", "./testdata/test.template")
+	typ, err := NewType("test", "./testdata/test.template")
+	if err != nil {
+		t.Fatal(err)
+	}
 	typ.CreateDir("./testdata/result/")
 	typ.Extension = ".go"
-	typ.Generate()
+	if err := typ.Generate(); err != nil {
+		t.Error(err)
+	}
 
 	// Read the file, make sure its content is valid.
 	c, err := ioutil.ReadFile("./testdata/result/test.go")
@@ -92,8 +107,50 @@ func TestGenerate(t *testing.T) {
 	os.RemoveAll("./testdata/result")
 }
 
-func expectPanic(msg string) {
-	if err := recover(); err == nil {
-		panic(msg)
+func TestRender(t *testing.T) {
+	typ, err := NewType("test", "./testdata/test.template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ.Path = "./testdata/result"
+	typ.Extension = ".go"
+	if res, err := typ.Render(); err != nil || strings.TrimSpace(string(res)) != "package test" {
+		t.Errorf("Rendered file expected to contain 'package test', instead it is '%s', error: %v.", res, err)
+	}
+	if _, err := os.Stat("./testdata/result"); !os.IsNotExist(err) {
+		t.Errorf("Render is not expected to create files.")
+	}
+	if f := typ.File(); f != "testdata/result/test.go" {
+		t.Errorf(`Incorrect path of the file: "%s".`, f)
+	}
+}
+
+func TestSave(t *testing.T) {
+	os.MkdirAll("./testdata/result", 0755)
+	defer os.RemoveAll("./testdata/result")
+
+	for _, c := range []string{"package test\n", "package result\n"} {
+		if err := Save("./testdata/result/test.go", []byte(c)); err != nil {
+			t.Error(err)
+		}
+	}
+	if c, err := ioutil.ReadFile("./testdata/result/test.go"); err != nil || string(c) != "package result\n" {
+		t.Errorf(`File expected to be replaced, got "%s". Error: %v.`, c, err)
+	}
+	if fs, _ := ioutil.ReadDir("./testdata/result"); len(fs) != 1 {
+		t.Errorf("Temporary files are expected to be removed, got %d files.", len(fs))
+	}
+}
+
+func TestParseType(t *testing.T) {
+	typ, err := ParseType("test", "test.template", "package <@.package>\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := typ.Render(); err != nil || strings.TrimSpace(string(res)) != "package test" || typ.TemplateName != "test.template" {
+		t.Errorf("Template expected to be parsed from the string, got '%s', error: %v.", res, err)
+	}
+	if _, err := ParseType("test", "test.template", "<@.package"); err == nil {
+		t.Error("Template is incorrect, an error expected.")
 	}
 }
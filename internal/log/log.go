@@ -5,11 +5,17 @@
 package log
 
 import (
+	"encoding/json"
 	"io"
 	"log"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
 )
 
 // Default loggers that are used by packages of Goal project.
@@ -20,37 +26,145 @@ var (
 	Trace *log.Logger
 )
 
+// Level is a severity of messages of a logger.
+type Level int
+
+// Levels of the default loggers, from the least severe to the most one.
+const (
+	LevelTrace Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelNames are names of the levels that are used in JSON records.
+var levelNames = map[Level]string{
+	LevelTrace: "trace",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// Config represents settings of the default loggers.
+type Config struct {
+	Level Level  // Messages of the less severe levels are discarded.
+	Color bool   // Color is used only if the output is a terminal.
+	JSON  bool   // One JSON record per line is written instead of text.
+	Tool  string // Name of the tool that is added to JSON records.
+}
+
+var (
+	// mu protects the settings and makes sure messages of different
+	// loggers that are written concurrently do not interleave.
+	mu sync.Mutex
+
+	settings = Config{
+		Level: LevelInfo,
+		Color: os.Getenv("NO_COLOR") == "",
+	}
+
+	// loggers are the default loggers and their contexts.
+	loggers = map[*log.Logger]*context{}
+)
+
+// Configure changes the settings of the default loggers.
+// It is safe to call it while the loggers are being used.
+func Configure(c Config) {
+	mu.Lock()
+	settings = c
+	mu.Unlock()
+
+	// The loggers hold their own locks while they call Write
+	// that acquires mu, so they are updated after mu is released.
+	for l, ctx := range loggers {
+		// Time and level are parts of the JSON records,
+		// so the text prefix is not needed.
+		if c.JSON {
+			l.SetPrefix("")
+			l.SetFlags(0)
+			continue
+		}
+		l.SetPrefix(ctx.prefix)
+		l.SetFlags(log.Ltime)
+	}
+}
+
+// record is a representation of a message in JSON format.
+type record struct {
+	Level   string `json:"level"`
+	Time    string `json:"time"`
+	Message string `json:"message"`
+	Tool    string `json:"tool,omitempty"`
+}
+
 // context is a type that implements io.Writer interface.
 type context struct {
-	c *color.Color
-	w io.Writer
+	c      *color.Color
+	w      io.Writer
+	tty    bool   // Whether w is a terminal.
+	level  Level  // Severity of the messages that are written.
+	prefix string // Prefix of the messages in text format.
 }
 
 // Write is a method that's required for context type
 // to be an implementation of io.Writer interface.
 // It writes data to the predefined writer using
-// the previously defined color.
+// the previously defined color and format.
+// Data of the levels that are below the threshold are discarded.
 func (c *context) Write(d []byte) (n int, err error) {
-	// Set the color that has been registered for this logger.
-	c.c.Set()
-	defer color.Unset() // Stop using the color after we're done.
+	mu.Lock()
+	defer mu.Unlock()
 
-	// Write the result to writer.
-	return c.w.Write(d)
+	switch {
+	case c.level < settings.Level:
+	case settings.JSON:
+		err = json.NewEncoder(c.w).Encode(record{
+			Level:   levelNames[c.level],
+			Time:    time.Now().Format(time.RFC3339),
+			Message: strings.TrimSuffix(string(d), "\n"),
+			Tool:    settings.Tool,
+		})
+	case settings.Color && c.tty:
+		// Color's methods write escape sequences to the requested
+		// writer only rather than changing the global state.
+		_, err = c.c.Fprint(c.w, string(d))
+	default:
+		_, err = c.w.Write(d)
+	}
+	return len(d), err
 }
 
 // newContext allocates and returns a new context.
-func newContext(w io.Writer, cs ...color.Attribute) *context {
-	return &context{
-		c: color.New(cs...),
-		w: w,
+func newContext(w io.Writer, level Level, prefix string, cs ...color.Attribute) *context {
+	c := &context{
+		c:      color.New(cs...),
+		w:      w,
+		level:  level,
+		prefix: prefix,
+	}
+	c.c.EnableColor() // Whether to use colors is decided by Write.
+
+	// Escape sequences must be translated for the terminals of Windows.
+	if f, ok := w.(*os.File); ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())) {
+		c.w = colorable.NewColorable(f)
+		c.tty = true
 	}
+	return c
+}
+
+// newLogger allocates a new logger and registers it
+// so it is affected by Configure.
+func newLogger(w io.Writer, level Level, prefix string, cs ...color.Attribute) *log.Logger {
+	c := newContext(w, level, prefix, cs...)
+	l := log.New(c, prefix, log.Ltime)
+	loggers[l] = c
+	return l
 }
 
 func init() {
 	// Initialize default loggers.
-	Error = log.New(newContext(os.Stderr, color.FgRed, color.Bold), "ERROR: ", log.Ltime)
-	Warn = log.New(newContext(os.Stderr, color.FgYellow), "WARN: ", log.Ltime)
-	Info = log.New(newContext(os.Stdout, color.FgGreen), "INFO: ", log.Ltime)
-	Trace = log.New(newContext(os.Stdout, color.FgCyan), "TRACE: ", log.Ltime)
+	Error = newLogger(os.Stderr, LevelError, "ERROR: ", color.FgRed, color.Bold)
+	Warn = newLogger(os.Stderr, LevelWarn, "WARN: ", color.FgYellow)
+	Info = newLogger(os.Stdout, LevelInfo, "INFO: ", color.FgGreen)
+	Trace = newLogger(os.Stdout, LevelTrace, "TRACE: ", color.FgCyan)
 }
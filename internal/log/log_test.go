@@ -0,0 +1,135 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+func TestContextWrite_Level(t *testing.T) {
+	defer Configure(settings)
+
+	var buf bytes.Buffer
+	c := newContext(&buf, LevelInfo, "INFO: ")
+	Configure(Config{Level: LevelWarn})
+	c.Write([]byte("hidden\n"))
+	if buf.Len() != 0 {
+		t.Errorf(`Messages below the threshold are expected to be discarded, got "%s".`, buf.String())
+	}
+
+	Configure(Config{Level: LevelInfo})
+	c.Write([]byte("shown\n"))
+	if buf.String() != "shown\n" {
+		t.Errorf(`Expected "shown\n", got "%s".`, buf.String())
+	}
+}
+
+func TestContextWrite_Color(t *testing.T) {
+	defer Configure(settings)
+
+	var buf bytes.Buffer
+	c := newContext(&buf, LevelError, "ERROR: ", color.FgRed)
+	Configure(Config{Level: LevelTrace, Color: true})
+	c.Write([]byte("test\n"))
+	if buf.String() != "test\n" {
+		t.Errorf(`Colors are expected to be used for terminals only, got %q.`, buf.String())
+	}
+
+	buf.Reset()
+	c.tty = true
+	c.Write([]byte("test\n"))
+	if !strings.Contains(buf.String(), "\x1b[31m") {
+		t.Errorf(`Colored output expected, got %q.`, buf.String())
+	}
+}
+
+func TestLogger_JSON(t *testing.T) {
+	defer Configure(settings)
+
+	var buf bytes.Buffer
+	l := newLogger(&buf, LevelWarn, "WARN: ")
+	defer delete(loggers, l)
+
+	Configure(Config{Level: LevelTrace, JSON: true, Tool: "generate handlers"})
+	l.Printf("Something %s.", "happened")
+	r := record{}
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf(`One JSON record expected, got "%s". Error: %v.`, buf.String(), err)
+	}
+	if r.Level != "warn" || r.Message != "Something happened." || r.Tool != "generate handlers" || r.Time == "" {
+		t.Errorf(`Incorrect record: %#v.`, r)
+	}
+
+	Configure(Config{Level: LevelTrace})
+	buf.Reset()
+	l.Print("test")
+	if !strings.HasPrefix(buf.String(), "WARN: ") {
+		t.Errorf(`Prefix is expected to be restored in text format, got "%s".`, buf.String())
+	}
+}
+
+func TestContextWrite_Concurrent(t *testing.T) {
+	defer Configure(settings)
+	Configure(Config{Level: LevelTrace, Color: true})
+
+	var buf bytes.Buffer
+	cs := []*context{
+		newContext(&buf, LevelInfo, "", color.FgGreen),
+		newContext(&buf, LevelWarn, "", color.FgYellow),
+	}
+	for i := range cs {
+		cs[i].tty = true
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(c *context) {
+			defer wg.Done()
+			c.Write([]byte("message\n"))
+		}(cs[i%len(cs)])
+	}
+	wg.Wait()
+
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.Contains(l, "message") {
+			t.Errorf(`Messages are expected not to interleave, got line %q.`, l)
+		}
+	}
+}
+
+func TestConfigure_Concurrent(t *testing.T) {
+	defer Configure(settings)
+
+	var buf bytes.Buffer
+	l := newLogger(&buf, LevelInfo, "INFO: ")
+	defer delete(loggers, l)
+
+	done := make(chan bool)
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				l.Println("message")
+			}()
+			go func(i int) {
+				defer wg.Done()
+				Configure(Config{Level: LevelTrace, JSON: i%2 == 0})
+			}(i)
+		}
+		wg.Wait()
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Loggers are expected to be configured while they are used, but they are deadlocked.")
+	}
+}
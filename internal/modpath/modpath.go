@@ -0,0 +1,386 @@
+// Package modpath is used for transformation of import paths of Go packages
+// into directories and vice versa. Go modules are supported: packages of
+// the main module, modules that are replaced by "replace" directives,
+// and required modules from the module cache or "vendor" directory
+// are resolved using go.mod that is parsed by the go tool.
+// If there is no go.mod file or module mode is disabled (GO111MODULE=off),
+// "$GOPATH/src" is used (see github.com/conveyer/importpath).
+package modpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/conveyer/importpath"
+)
+
+// FileName is a name of the file that defines a module.
+const FileName = "go.mod"
+
+// Enabled checks whether module mode is not disabled explicitly.
+func Enabled() bool {
+	return os.Getenv("GO111MODULE") != "off"
+}
+
+// ToImport gets a path of a directory and returns its import path.
+// If the directory is inside of a module, path of the module
+// plus relative path of the directory is returned.
+// Otherwise, the directory is expected to be inside "$GOPATH/src".
+func ToImport(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if Enabled() {
+		if f, err := Find(abs); err != nil {
+			return "", err
+		} else if f != nil {
+			rel, err := filepath.Rel(f.Dir, abs)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(f.Path, filepath.ToSlash(rel)), nil
+		}
+	}
+	return importpath.ToImport(abs)
+}
+
+// Clean gets an import path or a relative / absolute path of a directory
+// and returns an import path. E.g. "./controllers" of the module
+// "github.com/user/app" is transformed into "github.com/user/app/controllers".
+func Clean(imp string) (string, error) {
+	norm := strings.TrimRight(filepath.ToSlash(imp), "/")
+	if norm == "" {
+		norm = "."
+	}
+	if !local(norm) {
+		return norm, nil
+	}
+	return ToImport(norm)
+}
+
+// ToPath gets an import path and returns a directory of the package.
+// Relative paths are transformed into absolute ones.
+// The following places are checked in order:
+//  0. "vendor" directory of the main module, i.e. the one the current
+//     directory belongs to, if the modules it requires are vendored;
+//  1. "replace" directives of the main module;
+//  2. The main module itself;
+//  3. Modules it requires, in the module cache;
+//  4. Standard library, i.e. "$GOROOT/src";
+//  5. "$GOPATH/src" (the current directory is not required to be inside it);
+//  6. Module cache if the package belongs to goal's own module.
+//
+// If the package cannot be found, a path in the main module or
+// "$GOPATH/src" is returned if the import path belongs to them.
+func ToPath(imp string) (string, error) {
+	if local(filepath.ToSlash(imp)) {
+		return filepath.Abs(imp)
+	}
+	imp = strings.TrimRight(imp, "/")
+
+	// Try to find the package using go.mod of the main module.
+	if Enabled() {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		f, err := Find(wd)
+		if err != nil {
+			return "", err
+		}
+		if f != nil {
+			if dir, ok := f.ToPath(imp); ok {
+				return dir, nil
+			}
+		}
+	}
+
+	// Check packages of the standard library.
+	if d := join(filepath.Join(build.Default.GOROOT, "src"), imp); exists(d) {
+		return d, nil
+	}
+
+	// Check "$GOPATH/src" and goal's own module.
+	dir, gopathErr := importpath.ToPath(imp)
+	if gopathErr == nil && exists(dir) {
+		return dir, nil
+	}
+	for _, p := range filepath.SplitList(build.Default.GOPATH) {
+		if d := join(filepath.Join(p, "src"), imp); exists(d) {
+			return d, nil
+		}
+	}
+	if d, ok := own(imp); ok && exists(d) {
+		return d, nil
+	}
+	if gopathErr == nil {
+		return dir, nil
+	}
+	return "", fmt.Errorf(`cannot find package "%s" in the main module, module cache, or GOPATH`, imp)
+}
+
+// Cache returns a directory of the module cache.
+func Cache() string {
+	if d := os.Getenv("GOMODCACHE"); d != "" {
+		return d
+	}
+	return filepath.Join(filepath.SplitList(build.Default.GOPATH)[0], "pkg", "mod")
+}
+
+// CachePath returns a directory of the module of the requested version
+// in the module cache. Upper case letters are escaped as Go tool does,
+// i.e. "github.com/User/x" is stored as "github.com/!user/x".
+func CachePath(mod, version string) string {
+	var b strings.Builder
+	for _, r := range mod + "@" + version {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return filepath.Join(Cache(), filepath.FromSlash(b.String()))
+}
+
+// File represents a parsed go.mod file.
+type File struct {
+	Dir     string                 // Directory of the go.mod file, i.e. root of the module.
+	Path    string                 // Path of the module.
+	Require map[string]string      // Paths of required modules and their versions.
+	Replace map[string]Replacement // Paths of replaced modules and their replacements.
+	Vendor  bool                   // Whether required modules are loaded from "vendor" directory.
+}
+
+// Replacement is a right side of a "replace" directive.
+// Version is empty if Path is a local directory.
+type Replacement struct {
+	Path    string
+	Version string
+}
+
+// Find looks for a go.mod file in the directory and its parents.
+// If it is found, the file is parsed and returned.
+// Otherwise, nil and no error.
+func Find(dir string) (*File, error) {
+	for {
+		fi, err := os.Stat(filepath.Join(dir, FileName))
+		if err == nil {
+			return cached(dir, fi)
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+var (
+	// files are parsed go.mod files and their modification times,
+	// their directories are used as keys.
+	files   = map[string]cache{}
+	filesMu sync.Mutex
+)
+
+// cache represents a parsed go.mod file.
+type cache struct {
+	f       *File
+	modTime time.Time
+}
+
+// cached returns the go.mod file of the directory. It is parsed
+// only if it has been modified since the previous call.
+func cached(dir string, fi os.FileInfo) (*File, error) {
+	filesMu.Lock()
+	defer filesMu.Unlock()
+	if c, ok := files[dir]; ok && c.modTime.Equal(fi.ModTime()) {
+		return c.f, nil
+	}
+	f, err := Parse(dir)
+	if err != nil {
+		return nil, err
+	}
+	files[dir] = cache{f: f, modTime: fi.ModTime()}
+	return f, nil
+}
+
+// modJSON is a representation of the go.mod file
+// printed by "go mod edit -json".
+type modJSON struct {
+	Module struct {
+		Path string
+	}
+	Go      string
+	Require []struct {
+		Path, Version string
+	}
+	Replace []struct {
+		Old, New struct {
+			Path, Version string
+		}
+	}
+}
+
+// Parse gets a directory of a go.mod file and returns the file
+// as a File. The file is parsed by "go mod edit -json", so the go tool
+// must be installed. Only "module", "go", "require", and "replace"
+// directives are taken into account.
+func Parse(dir string) (*File, error) {
+	cmd := exec.Command("go", "mod", "edit", "-json", filepath.Join(dir, FileName))
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(`cannot parse "%s": %v %s`, filepath.Join(dir, FileName), err, strings.TrimSpace(stderr.String()))
+	}
+	var m modJSON
+	if err := json.Unmarshal(out, &m); err != nil {
+		return nil, err
+	}
+	if m.Module.Path == "" {
+		return nil, fmt.Errorf(`%s: module path is not defined`, filepath.Join(dir, FileName))
+	}
+
+	f := &File{
+		Dir:     dir,
+		Path:    m.Module.Path,
+		Require: map[string]string{},
+		Replace: map[string]Replacement{},
+		Vendor:  vendored(dir, m.Go),
+	}
+	for _, r := range m.Require {
+		f.Require[r.Path] = r.Version
+	}
+	for _, r := range m.Replace {
+		f.Replace[r.Old.Path] = Replacement{Path: r.New.Path, Version: r.New.Version}
+	}
+	return f, nil
+}
+
+// vendored checks whether the go tool loads required modules
+// of the module from its "vendor" directory. It does so if "-mod=vendor"
+// flag is used or if the directory exists and the module requires
+// Go 1.14 or newer, unless another "-mod" flag is used.
+func vendored(dir, goVersion string) bool {
+	for _, f := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if strings.HasPrefix(f, "-mod=") {
+			return f == "-mod=vendor"
+		}
+	}
+	if !exists(filepath.Join(dir, "vendor", "modules.txt")) {
+		return false
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(goVersion, "%d.%d", &major, &minor); err != nil {
+		return false
+	}
+	return major > 1 || major == 1 && minor >= 14
+}
+
+// ToPath returns a directory of the package with the requested import path
+// and true if the package belongs to the module, one of the modules
+// it replaces, or requires. Otherwise, empty string and false are returned.
+// If modules are vendored, packages of the required ones are in "vendor"
+// directory of the module, replaced modules are vendored, too.
+func (f *File) ToPath(imp string) (string, bool) {
+	reqs := []string{}
+	for mod := range f.Require {
+		reqs = append(reqs, mod)
+	}
+	if _, ok := longest(imp, reqs); ok && f.Vendor {
+		return join(filepath.Join(f.Dir, "vendor"), imp), true
+	}
+
+	mods := []string{}
+	for mod := range f.Replace {
+		mods = append(mods, mod)
+	}
+	if mod, ok := longest(imp, mods); ok {
+		r := f.Replace[mod]
+		root := CachePath(r.Path, r.Version)
+		if r.Version == "" {
+			root = r.Path
+			if !filepath.IsAbs(root) {
+				root = filepath.Join(f.Dir, filepath.FromSlash(root))
+			}
+		}
+		return join(root, imp[len(mod):]), true
+	}
+	if mod, ok := longest(imp, []string{f.Path}); ok { // The main module.
+		return join(f.Dir, imp[len(mod):]), true
+	}
+	if mod, ok := longest(imp, reqs); ok {
+		return join(CachePath(mod, f.Require[mod]), imp[len(mod):]), true
+	}
+	return "", false
+}
+
+// Own returns path and version of the module goal has been built from
+// and true. If the version is not known (e.g. goal has been built
+// in GOPATH mode or from a local directory), false is returned.
+func Own() (mod, version string, ok bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return "", "", false
+	}
+	return info.Main.Path, info.Main.Version, true
+}
+
+// own returns a directory of the package in the module cache if it
+// belongs to the module goal has been built from, and true.
+// Otherwise, empty string and false.
+func own(imp string) (string, bool) {
+	mod, version, ok := Own()
+	if !ok {
+		return "", false
+	}
+	if rel, ok := longest(imp, []string{mod}); ok {
+		return join(CachePath(mod, version), imp[len(rel):]), true
+	}
+	return "", false
+}
+
+// longest returns the longest of the module paths
+// that the import path belongs to and true.
+func longest(imp string, mods []string) (res string, ok bool) {
+	for _, mod := range mods {
+		if (imp == mod || strings.HasPrefix(imp, mod+"/")) && len(mod) > len(res) {
+			res, ok = mod, true
+		}
+	}
+	return
+}
+
+// join adds a slash separated relative path to the directory.
+func join(dir, rel string) string {
+	return filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(rel, "/")))
+}
+
+// local checks whether a slash separated path is relative or absolute
+// path of a directory rather than an import path.
+func local(p string) bool {
+	return p == "." || p == ".." || strings.HasPrefix(p, "./") ||
+		strings.HasPrefix(p, "../") || strings.HasPrefix(p, "/") || filepath.IsAbs(p)
+}
+
+// exists checks whether the path exists.
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
@@ -0,0 +1,167 @@
+package modpath
+
+import (
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var goMod = `module github.com/user/app // The main module.
+
+go 1.16
+
+require github.com/goaltools/goal v0.1.0
+
+require (
+	github.com/goaltools/contrib v0.2.0
+	"github.com/Upper/case" v1.0.0 // indirect
+)
+
+replace github.com/goaltools/goal => ../goal
+
+replace (
+	github.com/goaltools/contrib v0.2.0 => github.com/fork/contrib v0.2.1
+)
+`
+
+func TestParse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goal-modpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, FileName), []byte(goMod), 0644)
+
+	f, err := Parse(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &File{
+		Dir:  dir,
+		Path: "github.com/user/app",
+		Require: map[string]string{
+			"github.com/goaltools/goal":    "v0.1.0",
+			"github.com/goaltools/contrib": "v0.2.0",
+			"github.com/Upper/case":        "v1.0.0",
+		},
+		Replace: map[string]Replacement{
+			"github.com/goaltools/goal":    {Path: "../goal"},
+			"github.com/goaltools/contrib": {Path: "github.com/fork/contrib", Version: "v0.2.1"},
+		},
+	}
+	if !reflect.DeepEqual(f, exp) {
+		t.Errorf("Incorrect result of Parse. Expected %#v, got %#v.", exp, f)
+	}
+
+	// Modules are vendored if "vendor/modules.txt" exists
+	// unless another mode is requested by GOFLAGS.
+	defer os.Setenv("GOFLAGS", os.Getenv("GOFLAGS"))
+	os.MkdirAll(filepath.Join(dir, "vendor"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "vendor", "modules.txt"), nil, 0644)
+	for flags, exp := range map[string]bool{"": true, "-mod=mod": false, "-mod=vendor": true} {
+		os.Setenv("GOFLAGS", flags)
+		if f, err := Parse(dir); err != nil || f.Vendor != exp {
+			t.Errorf(`GOFLAGS="%s": modules are expected to be vendored: %v, got %v, %v.`, flags, exp, f, err)
+		}
+	}
+
+	for _, d := range []string{"go 1.16\n", "module\n", "module x\nrequire y\n", "module x\nreplace y\n"} {
+		ioutil.WriteFile(filepath.Join(dir, FileName), []byte(d), 0644)
+		if _, err := Parse(dir); err == nil {
+			t.Errorf(`"%s": error expected.`, d)
+		}
+	}
+}
+
+func TestFileToPath(t *testing.T) {
+	os.Setenv("GOMODCACHE", "/cache")
+	defer os.Unsetenv("GOMODCACHE")
+
+	f := &File{
+		Dir:  filepath.FromSlash("/app"),
+		Path: "github.com/user/app",
+		Require: map[string]string{
+			"github.com/goaltools/goal":    "v0.1.0",
+			"github.com/goaltools/contrib": "v0.2.0",
+			"github.com/Upper/case":        "v1.0.0",
+		},
+		Replace: map[string]Replacement{
+			"github.com/goaltools/goal":    {Path: "../goal"},
+			"github.com/goaltools/contrib": {Path: "github.com/fork/contrib", Version: "v0.2.1"},
+		},
+	}
+	for imp, exp := range map[string]string{
+		"github.com/user/app":                        "/app",
+		"github.com/user/app/controllers":            "/app/controllers",
+		"github.com/goaltools/goal/strconv":          "/goal/strconv",
+		"github.com/goaltools/contrib/routers/denco": "/cache/github.com/fork/contrib@v0.2.1/routers/denco",
+		"github.com/Upper/case/pkg":                  "/cache/github.com/!upper/case@v1.0.0/pkg",
+		"github.com/Upper/casepkg":                   "",
+		"github.com/unknown/pkg":                     "",
+	} {
+		res, ok := f.ToPath(imp)
+		if exp = filepath.FromSlash(exp); res != exp || ok != (exp != "") {
+			t.Errorf(`"%s": expected "%s", got "%s".`, imp, exp, res)
+		}
+	}
+
+	f.Vendor = true
+	for imp, exp := range map[string]string{
+		"github.com/user/app/controllers":            "/app/controllers",
+		"github.com/goaltools/goal/strconv":          "/app/vendor/github.com/goaltools/goal/strconv",
+		"github.com/goaltools/contrib/routers/denco": "/app/vendor/github.com/goaltools/contrib/routers/denco",
+		"github.com/unknown/pkg":                     "",
+	} {
+		res, ok := f.ToPath(imp)
+		if exp = filepath.FromSlash(exp); res != exp || ok != (exp != "") {
+			t.Errorf(`"%s": expected "%s", got "%s".`, imp, exp, res)
+		}
+	}
+}
+
+func TestToImport_ToPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goal-modpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dir, _ = filepath.EvalSymlinks(dir)
+	sub := filepath.Join(dir, "controllers")
+	os.MkdirAll(sub, 0755)
+	ioutil.WriteFile(filepath.Join(dir, FileName), []byte(goMod), 0644)
+
+	old, _ := os.Getwd()
+	os.Chdir(sub)
+	defer os.Chdir(old)
+	defer os.Setenv("GO111MODULE", os.Getenv("GO111MODULE"))
+	os.Setenv("GO111MODULE", "on")
+
+	if imp, err := ToImport("."); err != nil || imp != "github.com/user/app/controllers" {
+		t.Errorf(`Import path of the current directory is incorrect: "%s", %v.`, imp, err)
+	}
+	if imp, err := Clean("../"); err != nil || imp != "github.com/user/app" {
+		t.Errorf(`Cleaned import path is incorrect: "%s", %v.`, imp, err)
+	}
+	if p, err := ToPath("github.com/user/app/controllers"); err != nil || p != sub {
+		t.Errorf(`Expected "%s", got "%s", %v.`, sub, p, err)
+	}
+	if p, err := ToPath("net/url"); err != nil || p != filepath.Join(build.Default.GOROOT, "src", "net", "url") {
+		t.Errorf(`Packages of the standard library are expected to be found, got "%s", %v.`, p, err)
+	}
+	if p, err := ToPath("./views"); err != nil || p != filepath.Join(sub, "views") {
+		t.Errorf(`Relative paths are expected to be made absolute, got "%s", %v.`, p, err)
+	}
+}
+
+func TestCachePath(t *testing.T) {
+	os.Setenv("GOMODCACHE", "/cache")
+	defer os.Unsetenv("GOMODCACHE")
+
+	exp := filepath.FromSlash("/cache/github.com/!burnt!sushi/toml@v1.0.0")
+	if p := CachePath("github.com/BurntSushi/toml", "v1.0.0"); p != exp {
+		t.Errorf(`Expected "%s", got "%s".`, exp, p)
+	}
+}
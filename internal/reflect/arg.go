@@ -25,6 +25,18 @@ func (as Args) Filter(fn func(f *Arg) bool) (res Args) {
 	return res
 }
 
+// instantiate returns a copy of the arguments with the type parameters
+// replaced by the type arguments, see Type.Instantiate.
+func (as Args) instantiate(params []string, args []*Type) (res Args, err error) {
+	for _, v := range as {
+		if v.Type, err = v.Type.Instantiate(params, args); err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
 // processFieldList expects an ast FieldList as input parameter.
 // The list is transformed into Args.
 func processFieldList(fields *ast.FieldList) (list Args) {
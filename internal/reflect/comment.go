@@ -2,6 +2,7 @@ package reflect
 
 import (
 	"go/ast"
+	"strings"
 )
 
 // Comments is a type that is used for representation of a comments list.
@@ -18,6 +19,21 @@ func (cs Comments) Filter(fn func(s string) bool) (res Comments) {
 	return res
 }
 
+// Directives returns arguments of the comments that start with "//@"
+// and the requested directive name.
+// E.g. for "//@body input" and "body" it returns [["input"]].
+func (cs Comments) Directives(name string) (res [][]string) {
+	for _, c := range cs {
+		if !strings.HasPrefix(c, "//@") {
+			continue
+		}
+		if ws := strings.Fields(c[3:]); len(ws) > 0 && ws[0] == name {
+			res = append(res, ws[1:])
+		}
+	}
+	return
+}
+
 // processCommentGroup is a simple function that transforms *ast.CommentGroup
 // into a slice of strings.
 func processCommentGroup(group *ast.CommentGroup) (list Comments) {
@@ -7,6 +7,24 @@ import (
 	"testing"
 )
 
+func TestCommentsDirectives(t *testing.T) {
+	cs := Comments{
+		"// Create is an action.",
+		"//@post /users",
+		"//@body input",
+		"//@layout  from  Jan 2, 2006",
+		"// @layout to 2006-01-02",
+		"//@layout",
+	}
+	exp := [][]string{{"from", "Jan", "2,", "2006"}, {}}
+	if res := cs.Directives("layout"); !reflect.DeepEqual(res, exp) {
+		t.Errorf("Incorrect Directives result. Expected %#v, got %#v.", exp, res)
+	}
+	if res := cs.Directives("get"); res != nil {
+		t.Errorf("No directives expected, got %#v.", res)
+	}
+}
+
 func TestCommentsFilter(t *testing.T) {
 	t1 := Comments{
 		"Comment1",
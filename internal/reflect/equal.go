@@ -125,6 +125,9 @@ func AssertEqualStruct(s1, s2 *Struct) error {
 	if !r.DeepEqual(s1.Comments, s2.Comments) {
 		return fmt.Errorf("comments of structs are not equal: %#v != %#v", s1.Comments, s2.Comments)
 	}
+	if !r.DeepEqual(s1.TypeParams, s2.TypeParams) {
+		return fmt.Errorf("type parameters of structs are not equal: %#v != %#v", s1.TypeParams, s2.TypeParams)
+	}
 	return AssertEqualArgs(s1.Fields, s2.Fields)
 }
 
@@ -180,6 +183,9 @@ func AssertEqualPkg(p1, p2 *Package) error {
 	if err := AssertEqualStructs(p1.Structs, p2.Structs); err != nil {
 		return err
 	}
+	if !r.DeepEqual(p1.Types, p2.Types) {
+		return fmt.Errorf("types of packages are not equal: %#v != %#v", p1.Types, p2.Types)
+	}
 	if err := AssertEqualFuncs(p1.Funcs, p2.Funcs); err != nil {
 		return err
 	}
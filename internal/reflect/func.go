@@ -1,6 +1,7 @@
 package reflect
 
 import (
+	"fmt"
 	"go/ast"
 )
 
@@ -28,6 +29,35 @@ type Func struct {
 	Results  Args     // A list of arguments the function returns.
 }
 
+// TypeParams returns names of type parameters of the method's receiver,
+// e.g. "T" in case of "func (c *Resource[T]) Index()". Receivers of the
+// methods of generic types must list all of them.
+func (f *Func) TypeParams() (ps []string) {
+	if f.Recv == nil || f.Recv.Type == nil {
+		return nil
+	}
+	for _, a := range f.Recv.Type.Args {
+		ps = append(ps, a.Name)
+	}
+	return
+}
+
+// Instantiate returns a copy of the method of a generic type with the type
+// parameters of its receiver replaced by the type arguments in parameters
+// and results, see Type.Instantiate. The receiver is not changed.
+// An error is returned if some of the types cannot be instantiated.
+func (f Func) Instantiate(args []*Type) (Func, error) {
+	ps := f.TypeParams()
+	var err error
+	if f.Params, err = f.Params.instantiate(ps, args); err != nil {
+		return Func{}, fmt.Errorf(`parameters of "%s": %v`, f.Name, err)
+	}
+	if f.Results, err = f.Results.instantiate(ps, args); err != nil {
+		return Func{}, fmt.Errorf(`results of "%s": %v`, f.Name, err)
+	}
+	return f, nil
+}
+
 // FilterGroups gets a condition function and a number of group functions.
 // It cuts off those Funcs that do not satisfy condition.
 // And then groups the rest of them.
@@ -199,3 +199,41 @@ func assertDeepEqualFuncs(fs1, fs2 Funcs) {
 		log.Error.Panic(err)
 	}
 }
+
+func TestFuncInstantiate(t *testing.T) {
+	pkg := getPackage(t, `package test
+			func (c *Resource[T]) Create(item T, items []T) (T, error) {
+				return item, nil
+			}
+		`,
+	)
+	f := processFuncDecl(pkg.Decls[0].(*ast.FuncDecl))
+	if ps := f.TypeParams(); strings.Join(ps, ",") != "T" {
+		t.Errorf(`Type parameters of the receiver expected to be ["T"], got %v.`, ps)
+	}
+	res, err := f.Instantiate([]*Type{{Name: "User"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v.", err)
+	}
+	exp := Func{
+		Name: "Create",
+		Params: Args{
+			{Name: "item", Type: &Type{Name: "User"}},
+			{Name: "items", Type: &Type{Name: "[]User"}},
+		},
+		Recv: &Arg{Name: "c", Type: &Type{Name: "Resource", Star: true, Args: []*Type{{Name: "T"}}}},
+		Results: Args{
+			{Type: &Type{Name: "User"}},
+			{Type: &Type{Name: "error"}},
+		},
+	}
+	if err := AssertEqualFunc(&res, &exp); err != nil {
+		t.Error(err)
+	}
+	if err := AssertEqualType(f.Params[0].Type, &Type{Name: "T"}); err != nil {
+		t.Errorf("The original function must not be changed: %v.", err)
+	}
+	if _, err := f.Instantiate([]*Type{{Name: "User", Star: true}}); err == nil {
+		t.Errorf(`Error expected as "[]T" with "T" = "*User" cannot be represented.`)
+	}
+}
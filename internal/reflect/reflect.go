@@ -4,13 +4,15 @@
 package reflect
 
 import (
+	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
-
-	"github.com/goaltools/goal/internal/log"
 )
 
 // Imports is a map of import paths in the following format:
@@ -32,6 +34,23 @@ type Package struct {
 	Methods Methods // Struct names and their Methods (functions with receivers).
 	Name    string  // Name of the package, e.g. "controllers".
 	Structs Structs // A list of struct types of the package.
+	Types   Types   // Named non-struct types of the package.
+}
+
+// Struct returns a declaration of the structure the type refers to
+// and true if the type is a local one, i.e. the structure is declared
+// in the package. The type may be a pointer.
+// Otherwise, nil and false are returned.
+func (p *Package) Struct(t *Type) (*Struct, bool) {
+	if t == nil || t.Package != "" {
+		return nil, false
+	}
+	for i := range p.Structs {
+		if p.Structs[i].Name == t.Name {
+			return &p.Structs[i], true
+		}
+	}
+	return nil, false
 }
 
 // Value checks whether requested import name exists in
@@ -79,11 +98,21 @@ func (i Imports) Name(file, value string) (string, bool) {
 // somename and somename_test.
 // If testPkg argument is false the first one will be returned.
 // Otherwise, the latter is returned.
-func ParseDir(path string, testPkg bool) *Package {
+// Files excluded by build constraints are ignored.
+// An error is returned if the directory cannot be parsed
+// or there is no requested package in it.
+func ParseDir(path string, testPkg bool) (*Package, error) {
 	fset := token.NewFileSet() // Positions are relative to fset.
-	pkgs, err := parser.ParseDir(fset, path, nil, parser.ParseComments)
+
+	// Files that are excluded by build constraints (e.g. "//go:build ignore")
+	// are skipped as they may belong to other packages.
+	filter := func(fi os.FileInfo) bool {
+		ok, err := build.Default.MatchFile(path, fi.Name())
+		return err == nil && ok
+	}
+	pkgs, err := parser.ParseDir(fset, path, filter, parser.ParseComments)
 	if err != nil {
-		log.Error.Panic(err)
+		return nil, err
 	}
 
 	// Just one package per directory is allowed.
@@ -100,6 +129,9 @@ func ParseDir(path string, testPkg bool) *Package {
 			break
 		}
 	}
+	if pkg == nil {
+		return nil, fmt.Errorf(`there is no go package in "%s"`, path)
+	}
 
 	// Iterating through files of the package and combining all declarations
 	// into single Package struct.
@@ -107,8 +139,18 @@ func ParseDir(path string, testPkg bool) *Package {
 		Imports: map[string]map[string]string{},
 		Methods: map[string]Funcs{},
 		Name:    pkg.Name,
+		Types:   Types{},
+	}
+	// Files are processed in sorted order, so declarations are
+	// listed the same way every time the package is parsed.
+	names := []string{}
+	for name := range pkg.Files {
+		names = append(names, name)
 	}
-	for name, file := range pkg.Files {
+	sort.Strings(names)
+	for _, name := range names {
+		file := pkg.Files[name]
+
 		// Extract functions, methods, sructures, and imports from file declarations.
 		fs, ms, ss, is := processDecls(file.Decls, filepath.ToSlash(name))
 
@@ -127,10 +169,15 @@ func ParseDir(path string, testPkg bool) *Package {
 			p.Structs = append(p.Structs, ss...)
 		}
 
+		// Add named types that are not structures.
+		for k, v := range processTypeDecls(file.Decls) {
+			p.Types[k] = v
+		}
+
 		// Add imports of the current file.
 		p.Imports[filepath.ToSlash(name)] = is
 	}
-	return p
+	return p, nil
 }
 
 // processDecls expects a list of declarations as an input
@@ -58,17 +58,37 @@ func TestImportsName(t *testing.T) {
 	}
 }
 
-func TestParseDir_IncorrectPath(t *testing.T) {
-	defer func() {
-		if err := recover(); err == nil {
-			t.Error("Incorrect path is expected to cause panic, but nothing happened.")
+func TestPackageStruct(t *testing.T) {
+	p, err := ParseDir("./testdata", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := p.Struct(&Type{Name: "Test", Star: true}); !ok || s.Name != "Test" {
+		t.Errorf(`Local structure "Test" is expected to be found, got %v, %v.`, s, ok)
+	}
+	for _, typ := range []*Type{
+		{Name: "Test", Package: "sample"},
+		{Name: "MapFunc"},
+		{Name: "string"},
+		nil,
+	} {
+		if s, ok := p.Struct(typ); ok {
+			t.Errorf(`"%v": structure is not expected to be found, got %v.`, typ, s)
 		}
-	}()
-	ParseDir("testdata/dir_that_does_not_exist", false)
+	}
+}
+
+func TestParseDir_IncorrectPath(t *testing.T) {
+	if _, err := ParseDir("testdata/dir_that_does_not_exist", false); err == nil {
+		t.Error("Incorrect path is expected to cause an error, but nothing happened.")
+	}
 }
 
 func TestParseDir(t *testing.T) {
-	p := ParseDir("./testdata", false)
+	p, err := ParseDir("./testdata", false)
+	if err != nil {
+		t.Fatal(err)
+	}
 	expRes := &Package{
 		Funcs: Funcs{
 			{
@@ -135,6 +155,9 @@ func TestParseDir(t *testing.T) {
 				Name: "Test",
 			},
 		},
+		Types: Types{
+			"MapFunc": &Type{Name: "map[string]reflect.Func"},
+		},
 
 		Imports: map[string]map[string]string{
 			"testdata/sample1.go": {
@@ -17,6 +17,8 @@ type Struct struct {
 	Fields   Args     // A list of fields that belong to this struct.
 	File     string   // Name of the file where the function is located.
 	Name     string   // Name of the struct, e.g. "Application".
+
+	TypeParams []string // Names of type parameters of a generic struct, e.g. "T" in case of "Resource[T any]".
 }
 
 // Filter returns a list of structures from members of a list
@@ -82,10 +84,18 @@ func processTypeSpec(spec *ast.TypeSpec) *Struct {
 	}
 
 	// Compose a structure and return it.
-	return &Struct{
+	s := &Struct{
 		Fields: processFieldList(structType.Fields),
 		Name:   spec.Name.Name,
 	}
+	if spec.TypeParams != nil {
+		for _, f := range spec.TypeParams.List {
+			for _, n := range f.Names {
+				s.TypeParams = append(s.TypeParams, n.Name)
+			}
+		}
+	}
+	return s
 }
 
 // processImportSpec gets ast import spec as input parameter
@@ -178,6 +178,23 @@ func TestProcessTypeSpec(t *testing.T) {
 	assertDeepEqualStruct(expRes, res)
 }
 
+func TestProcessTypeSpec_Generic(t *testing.T) {
+	pkg := getPackage(t, `package test
+			type Pair[K comparable, V any] struct {
+				Key K
+			}
+		`,
+	)
+	genDecl, _ := pkg.Decls[0].(*ast.GenDecl)
+	typeSpec, _ := genDecl.Specs[0].(*ast.TypeSpec)
+	exp := &Struct{
+		Fields:     Args{{Name: "Key", Type: &Type{Name: "K"}}},
+		Name:       "Pair",
+		TypeParams: []string{"K", "V"},
+	}
+	assertDeepEqualStruct(exp, processTypeSpec(typeSpec))
+}
+
 func TestProcessImportSpec(t *testing.T) {
 	pkg := getPackage(t, `package test
 			import(
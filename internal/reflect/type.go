@@ -3,21 +3,44 @@ package reflect
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"strings"
 )
 
 // Type represents a type of argument.
 type Type struct {
-	Name    string // Name of the type, e.g. "URL". It is empty if Decl is not nil.
-	Package string // Package name, e.g. "template" in case of "html/template".
-	Star    bool   // Star indicates whether it is a pointer.
+	Name    string  // Name of the type, e.g. "URL". It is empty if Decl is not nil.
+	Package string  // Package name, e.g. "template" in case of "html/template".
+	Star    bool    // Star indicates whether it is a pointer.
+	Args    []*Type // Type arguments of a generic type, e.g. "User" in case of "Resource[User]".
 }
 
+// Types is a map of named types that are not structures
+// and their underlying types, e.g. "UserID": int64.
+type Types map[string]*Type
+
 // String prints a type name, e.g. "*template.URL", "template.Template",
-// "Controller", "int64", etc.
+// "Controller", "int64", "[]time.Time", etc.
 func (t *Type) String() (name string) {
 	name = t.Name
 	if t.Package != "" {
-		name = t.Package + "." + name
+		// Prefixes of slices and variadic arguments are
+		// placed before the package name, e.g. "[]time.Time".
+		i := 0
+		for strings.HasPrefix(name[i:], "[]") {
+			i += 2
+		}
+		if strings.HasPrefix(name[i:], "...") {
+			i += 3
+		}
+		name = name[:i] + t.Package + "." + name[i:]
+	}
+	if len(t.Args) > 0 {
+		as := make([]string, len(t.Args))
+		for i := range t.Args {
+			as[i] = t.Args[i].String()
+		}
+		name += "[" + strings.Join(as, ", ") + "]"
 	}
 	if t.Star {
 		name = "*" + name
@@ -25,6 +48,53 @@ func (t *Type) String() (name string) {
 	return name
 }
 
+// Instantiate returns a copy of the type with the requested type parameters
+// replaced by the type arguments, e.g. "[]T" with "T" = "User" becomes "[]User".
+// Pointers cannot be elements of slices or be pointed to by Type, so an error
+// is returned if the result is such a type, e.g. "[]T" or "*T" with "T" = "*User".
+func (t *Type) Instantiate(params []string, args []*Type) (*Type, error) {
+	if t == nil {
+		return nil, nil
+	}
+	res := *t
+	res.Args = nil
+	for i := range t.Args {
+		a, err := t.Args[i].Instantiate(params, args)
+		if err != nil {
+			return nil, err
+		}
+		res.Args = append(res.Args, a)
+	}
+	if t.Package != "" {
+		return &res, nil
+	}
+	prefix := t.Name[:len(t.Name)-len(t.Base())]
+	for i := range params {
+		if i < len(args) && params[i] == t.Base() {
+			if args[i].Star && (prefix != "" || t.Star) {
+				return nil, fmt.Errorf(
+					`type "%s" cannot be instantiated with "%s" = "%s"`, t, params[i], args[i],
+				)
+			}
+			res = *args[i]
+			res.Name = prefix + res.Name
+			res.Star = res.Star || t.Star
+			break
+		}
+	}
+	return &res, nil
+}
+
+// Base returns the name of the type without prefixes of slices
+// and variadic arguments, e.g. "User" in case of "[]User".
+func (t *Type) Base() string {
+	name := t.Name
+	for strings.HasPrefix(name, "[]") {
+		name = name[2:]
+	}
+	return strings.TrimPrefix(name, "...")
+}
+
 // processType parses go ast tree related to types into
 // Type, a format that is used by this reflect package.
 func processType(typ interface{}) *Type {
@@ -60,6 +130,29 @@ func processType(typ interface{}) *Type {
 			t.Name = fmt.Sprintf("[]%s", t.Name) // Add "[]" to the type name.
 		}
 		return t
+	case *ast.IndexExpr:
+		// X is a generic type and Index is its only type argument.
+		t := processType(v.X)
+		a := processType(v.Index)
+		if t == nil || a == nil {
+			return nil
+		}
+		t.Args = []*Type{a}
+		return t
+	case *ast.IndexListExpr:
+		// X is a generic type and Indices are its type arguments.
+		t := processType(v.X)
+		if t == nil {
+			return nil
+		}
+		for i := range v.Indices {
+			a := processType(v.Indices[i])
+			if a == nil {
+				return nil
+			}
+			t.Args = append(t.Args, a)
+		}
+		return t
 	case *ast.MapType:
 		// Extract key and value's types.
 		t := &Type{}
@@ -85,3 +178,26 @@ func processType(typ interface{}) *Type {
 	}
 	return nil
 }
+
+// processTypeDecls gets a list of declarations and returns
+// named types that are declared there, except structures.
+// Types that are not supported by processType are ignored.
+func processTypeDecls(decls []ast.Decl) Types {
+	ts := Types{}
+	for _, decl := range decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			s, _ := spec.(*ast.TypeSpec) // TypeSpec is the only possible value, so ignoring second arg.
+			if _, ok := s.Type.(*ast.StructType); ok {
+				continue
+			}
+			if t := processType(s.Type); t != nil && t.Name != "" {
+				ts[s.Name.Name] = t
+			}
+		}
+	}
+	return ts
+}
@@ -1,6 +1,7 @@
 package reflect
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/goaltools/goal/internal/log"
@@ -24,6 +25,23 @@ func TestTypeString(t *testing.T) {
 			Name: "Controller",
 			Star: true,
 		},
+		"[]time.Time": {
+			Name:    "[]Time",
+			Package: "time",
+		},
+		"...http.Handler": {
+			Name:    "...Handler",
+			Package: "http",
+		},
+		"*crud.Resource[User, *models.Tag]": {
+			Name:    "Resource",
+			Package: "crud",
+			Star:    true,
+			Args: []*Type{
+				{Name: "User"},
+				{Name: "Tag", Package: "models", Star: true},
+			},
+		},
 	}
 	for exp, typ := range expRes {
 		if got := typ.String(); got != exp {
@@ -94,8 +112,89 @@ func TestProcessType(t *testing.T) {
 	}
 }
 
+func TestProcessType_Generic(t *testing.T) {
+	pkg := getPackage(t, `package test
+		type Sample struct {
+			Users *Resource[User]
+			Tags  crud.Pair[int, *models.Tag]
+			Bad   Resource[struct{}]
+		}
+	`)
+	expRes := []*Type{
+		{Name: "Resource", Star: true, Args: []*Type{{Name: "User"}}},
+		{Name: "Pair", Package: "crud", Args: []*Type{{Name: "int"}, {Name: "Tag", Package: "models", Star: true}}},
+		nil,
+	}
+	for i, v := range getFields(t, pkg).List {
+		if err := AssertEqualType(processType(v.Type), expRes[i]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTypeInstantiate(t *testing.T) {
+	params, args := []string{"T", "K"}, []*Type{{Name: "User"}, {Name: "Tag", Package: "models"}}
+	for _, v := range []struct {
+		typ, exp *Type
+	}{
+		{&Type{Name: "T"}, &Type{Name: "User"}},
+		{&Type{Name: "[]T"}, &Type{Name: "[]User"}},
+		{&Type{Name: "...K"}, &Type{Name: "...Tag", Package: "models"}},
+		{&Type{Name: "T", Star: true}, &Type{Name: "User", Star: true}},
+		{&Type{Name: "List", Args: []*Type{{Name: "K"}}}, &Type{Name: "List", Args: []*Type{{Name: "Tag", Package: "models"}}}},
+		{&Type{Name: "T", Package: "time"}, &Type{Name: "T", Package: "time"}},
+		{&Type{Name: "int"}, &Type{Name: "int"}},
+	} {
+		res, err := v.typ.Instantiate(params, args)
+		if err != nil {
+			t.Errorf(`"%s": unexpected error: %v.`, v.typ, err)
+			continue
+		}
+		if err := AssertEqualType(res, v.exp); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Pointers cannot be elements of slices or be pointed to.
+	args = []*Type{{Name: "User", Star: true}}
+	for _, typ := range []*Type{
+		{Name: "[]T"},
+		{Name: "...T"},
+		{Name: "T", Star: true},
+		{Name: "List", Args: []*Type{{Name: "[]T"}}},
+	} {
+		if res, err := typ.Instantiate(params, args); err == nil {
+			t.Errorf(`"%s": error expected, got "%s".`, typ, res)
+		}
+	}
+}
+
 func assertDeepEqualType(t1, t2 *Type) {
 	if err := AssertEqualType(t1, t2); err != nil {
 		log.Error.Panic(err)
 	}
 }
+
+func TestProcessTypeDecls(t *testing.T) {
+	pkg := getPackage(t, `package test
+		type UserID int64
+
+		type (
+			Names []string
+			Sample struct {
+				ID UserID
+			}
+			Time time.Time
+		)
+
+		var x int
+	`)
+	exp := Types{
+		"UserID": {Name: "int64"},
+		"Names":  {Name: "[]string"},
+		"Time":   {Name: "Time", Package: "time"},
+	}
+	if ts := processTypeDecls(pkg.Decls); !reflect.DeepEqual(ts, exp) {
+		t.Errorf("Incorrect types. Expected %#v, got %#v.", exp, ts)
+	}
+}
@@ -22,6 +22,11 @@ var (
 	realMethodsList = []string{
 		"GET", "HEAD", "POST", "PUT", "DELETE", "TRACE", "OPTIONS", "CONNECT", "PATCH",
 	}
+	// directives are comments that start with "//@" but are not routes,
+	// e.g. "//@body input". They are handled by other packages.
+	directives = map[string]bool{
+		"body": true, "layout": true, "validate": true, "param": true, "use": true, "skip": true,
+	}
 	routePartsSep = map[byte]bool{
 		' ': true, '\t': true,
 	}
@@ -130,6 +135,9 @@ func parseComment(c string) (method, pattern, label string, ok bool) {
 	// Make sure the comment contains a correct method.
 	// NB: They must be lowecased.
 	cs := splitN(c[3:], 3)
+	if directives[cs[0]] {
+		return
+	}
 	if _, ok = supportedMethods[cs[0]]; !ok {
 		log.Warn.Printf(
 			`Comment "%s contains incorrect method "%s". Supported ones are %v.`,
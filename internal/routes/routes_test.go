@@ -179,6 +179,10 @@ func TestParseComment(t *testing.T) {
 			comment: "//@PUT",
 			ok:      false,
 		},
+		{
+			comment: "//@body input",
+			ok:      false,
+		},
 		{
 			comment: "//@get",
 			method:  "GET",
@@ -0,0 +1,408 @@
+package strconv
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	r "reflect"
+	"sort"
+	"strings"
+
+	"github.com/goaltools/goal/internal/modpath"
+	"github.com/goaltools/goal/internal/reflect"
+)
+
+// FormTag is a name of the struct field tag that defines
+// a key the field is bound from, e.g.:
+//
+//	Name string `form:"name"`
+//
+// Fields with "-" key are ignored.
+const FormTag = "form"
+
+// LayoutTag is a name of the struct field tag that defines a layout
+// a field of time.Time type is parsed with, e.g.:
+//
+//	From time.Time `form:"from" layout:"02.01.2006"`
+//
+// Layouts of action parameters are defined using DirectiveLayout.
+const LayoutTag = "layout"
+
+// DirectiveLayout is a name of the comment directive that defines
+// a layout a parameter of time.Time type is parsed with, e.g.:
+//
+//	//@layout from 02.01.2006
+const DirectiveLayout = "layout"
+
+// Kinds of named types that are not supported by FnMap directly
+// but still can be bound.
+const (
+	textKind   = iota + 1 // Pointer to the type implements encoding.TextUnmarshaler.
+	stringKind            // Pointer to the type has "FromString(string) error" method.
+	convKind              // Underlying type of the named type is supported by FnMap.
+)
+
+// Binder is used for generation of code that binds arguments of
+// the types supported by strconv package, named types whose pointers
+// implement encoding.TextUnmarshaler or have FromString method,
+// named types of the supported underlying types, and local structures
+// whose exported fields are of such types or local structures, too.
+type Binder struct {
+	FnMap
+
+	// Pkg is a package the structures are declared in.
+	// If it is nil, only the types of FnMap are supported.
+	Pkg *reflect.Package
+
+	// PkgName is a name the package of the structures is
+	// imported as by the generated code, e.g. "contr".
+	PkgName string
+
+	// File is a name of the file the arguments are declared in.
+	// It is used for getting import paths of the types of other packages.
+	File string
+
+	// Load returns a parsed package by its import path and true.
+	// If it is nil, types of other packages are not supported.
+	Load func(imp string) (*reflect.Package, bool)
+
+	// Imports are import paths of other packages the generated code
+	// refers to and names they are imported as, e.g. "i0".
+	Imports map[string]string
+
+	// Layouts are names of the parameters of time.Time type
+	// and layouts they must be parsed with.
+	Layouts map[string]string
+
+	// Rules are names of the parameters and their validation rules.
+	Rules map[string][]string
+}
+
+// named represents a named type that is not supported
+// by FnMap directly but can be bound.
+type named struct {
+	kind  int
+	imp   string        // Import path of the package the type is declared in or "" if it is local.
+	under *reflect.Type // Underlying type if the kind is convKind.
+}
+
+// In returns a copy of the binder that is used for arguments
+// declared in the requested file.
+func (b Binder) In(file string) Binder {
+	b.File = file
+	return b
+}
+
+// For returns a copy of the binder that is used for parameters
+// of the requested function. Layouts and validation rules
+// of the parameters are extracted from the function's comments.
+func (b Binder) For(f *reflect.Func) Binder {
+	b.File = f.File
+	b.Layouts = map[string]string{}
+	for _, d := range f.Comments.Directives(DirectiveLayout) {
+		if len(d) > 1 {
+			b.Layouts[d[0]] = strings.Join(d[1:], " ")
+		}
+	}
+	b.Rules = map[string][]string{}
+	for _, d := range f.Comments.Directives(DirectiveValidate) {
+		if len(d) > 1 {
+			b.Rules[d[0]] = append(b.Rules[d[0]], d[1:]...)
+		}
+	}
+	return b
+}
+
+// Supported returns an error if the argument cannot be bound,
+// i.e. it is not of a supported by strconv type, a named type
+// that can be bound, or a local structure (or a pointer to it)
+// consisting of such types.
+func (b Binder) Supported(a reflect.Arg) error {
+	return b.supported(a, map[string]bool{})
+}
+
+// supported is an implementation of Supported. Seen is a set of
+// structures that are being checked, it is used to reject
+// recursive structures that cannot be bound.
+func (b Binder) supported(a reflect.Arg, seen map[string]bool) error {
+	if _, ok := b.FnMap[a.Type.String()]; ok {
+		return nil
+	}
+	if _, ok := b.named(a.Type); ok {
+		return nil
+	}
+	s, ok := b.structure(a.Type)
+	if !ok {
+		return fmt.Errorf(`argument "%s" is of unsupported type "%s"`, a.Name, a.Type)
+	}
+	if seen[s.Name] {
+		return fmt.Errorf(`structure "%s" is recursive`, s.Name)
+	}
+	seen[s.Name] = true
+	defer delete(seen, s.Name)
+	for _, f := range s.Fields {
+		if _, ok := key(f); !ok {
+			continue
+		}
+		if err := b.In(s.File).supported(f, seen); err != nil {
+			return fmt.Errorf(`field "%s" of "%s": %v`, name(f), s.Name, err)
+		}
+	}
+	return nil
+}
+
+// Deps returns sorted import paths of other packages
+// the code generated for the argument refers to.
+func (b Binder) Deps(a reflect.Arg) []string {
+	m := map[string]bool{}
+	b.deps(a, m, map[string]bool{})
+	res := []string{}
+	for imp := range m {
+		res = append(res, imp)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// deps is an implementation of Deps, found import paths
+// are added to the res map.
+func (b Binder) deps(a reflect.Arg, res, seen map[string]bool) {
+	if _, ok := b.FnMap[a.Type.String()]; ok {
+		return
+	}
+	if n, ok := b.named(a.Type); ok {
+		if n.imp != "" {
+			res[n.imp] = true
+		}
+		return
+	}
+	s, ok := b.structure(a.Type)
+	if !ok || seen[s.Name] {
+		return
+	}
+	seen[s.Name] = true
+	for _, f := range s.Fields {
+		if _, ok := key(f); ok {
+			b.In(s.File).deps(f, res, seen)
+		}
+	}
+}
+
+// Render is similar to FnMap.Render but supports named types
+// and local structures. A structure is rendered as a composite literal,
+// its fields are bound using keys from the "form" tags or names of the fields.
+// Keys of the fields of nested structures are joined with a dot,
+// e.g. "address.city".
+func (b Binder) Render(pkgName, vsName string, a reflect.Arg) (string, error) {
+	return b.render(pkgName, vsName, "", a)
+}
+
+// render generates binding code of the argument, its key
+// is prefixed with the requested prefix.
+func (b Binder) render(pkgName, vsName, prefix string, a reflect.Arg) (string, error) {
+	if _, ok := b.FnMap[a.Type.String()]; !ok {
+		if n, ok := b.named(a.Type); ok {
+			return b.renderNamed(pkgName, vsName, prefix+a.Name, a.Type, n)
+		}
+	}
+	s, ok := b.structure(a.Type)
+	if !ok {
+		a.Name = prefix + a.Name
+		if l := b.layout(a); l != "" {
+			return renderLayout(pkgName, vsName, a, l)
+		}
+		return b.FnMap.Render(pkgName, vsName, a)
+	}
+
+	// Layouts of the parameters are not applied to fields.
+	b.Layouts = nil
+
+	var buf bytes.Buffer
+	if a.Type.Star {
+		buf.WriteString("&")
+	}
+	fmt.Fprintf(&buf, "%s.%s{\n", b.PkgName, s.Name)
+	for _, f := range s.Fields {
+		k, ok := key(f)
+		if !ok {
+			continue
+		}
+
+		// Keys of the fields of nested structures are prefixed with
+		// the key of the structure unless it is embedded.
+		var v string
+		var err error
+		if _, ok := b.structure(f.Type); ok {
+			p := prefix
+			if f.Name != "" {
+				p += k + "."
+			}
+			v, err = b.In(s.File).render(pkgName, vsName, p, f)
+		} else {
+			v, err = b.In(s.File).render(pkgName, vsName, prefix, reflect.Arg{Name: k, Tag: f.Tag, Type: f.Type})
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "%s: %s,\n", name(f), v)
+	}
+	buf.WriteString("}")
+	return buf.String(), nil
+}
+
+// layout returns a layout the argument of time.Time or []time.Time type
+// must be parsed with. If the default layouts must be used,
+// empty string is returned.
+func (b Binder) layout(a reflect.Arg) string {
+	switch a.Type.String() {
+	case "time.Time", "[]time.Time":
+	default:
+		return ""
+	}
+	if l := r.StructTag(a.Tag).Get(LayoutTag); l != "" {
+		return l
+	}
+	return b.Layouts[a.Name]
+}
+
+// renderLayout generates binding code of the argument
+// of time.Time or []time.Time type with a custom layout.
+func renderLayout(pkgName, vsName string, a reflect.Arg, layout string) (string, error) {
+	if a.Type.Name == "Time" {
+		return fmt.Sprintf(`%s.TimeLayout(%s, "%s", %q)`, pkgName, vsName, a.Name, layout), nil
+	}
+	return fmt.Sprintf(`%s.TimesLayout(%s, "%s[]", %q)`, pkgName, vsName, a.Name, layout), nil
+}
+
+// renderNamed generates binding code of a named type
+// that is not supported by FnMap directly.
+func (b Binder) renderNamed(pkgName, vsName, key string, t *reflect.Type, n named) (string, error) {
+	typ := b.PkgName + "." + t.Name
+	if n.imp != "" {
+		alias, ok := b.Imports[n.imp]
+		if !ok {
+			return "", fmt.Errorf(`package "%s" is not imported`, n.imp)
+		}
+		typ = alias + "." + t.Name
+	}
+	switch n.kind {
+	case textKind:
+		return fmt.Sprintf(`func() (v %s) { %s.Text(%s, "%s", &v); return }()`, typ, pkgName, vsName, key), nil
+	case stringKind:
+		return fmt.Sprintf(`func() (v %s) { %s.FromString(%s, "%s", &v); return }()`, typ, pkgName, vsName, key), nil
+	}
+	v, err := b.FnMap.Render(pkgName, vsName, reflect.Arg{Name: key, Type: n.under})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s)", typ, v), nil
+}
+
+// named checks whether the type is a named type that is not supported
+// by FnMap directly but can be bound, i.e. its pointer implements
+// encoding.TextUnmarshaler, has FromString method, or its underlying
+// type is supported by FnMap. Pointers are not supported.
+func (b Binder) named(t *reflect.Type) (n named, ok bool) {
+	if t == nil || t.Star || b.Pkg == nil {
+		return
+	}
+	pkg := b.Pkg
+	if t.Package != "" {
+		if b.Load == nil {
+			return
+		}
+		if n.imp, ok = b.Pkg.Imports.Value(b.File, t.Package); !ok {
+			return
+		}
+		if pkg, ok = b.Load(n.imp); !ok {
+			return
+		}
+	}
+	for _, m := range pkg.Methods[t.Name] {
+		switch {
+		case method(m, "UnmarshalText", "[]byte"):
+			n.kind = textKind
+			return n, true
+		case method(m, "FromString", "string"):
+			n.kind = stringKind
+			return n, true
+		}
+	}
+	if u, ok := pkg.Types[t.Name]; ok && u.Package == "" {
+		if _, ok := b.FnMap[u.String()]; ok {
+			n.kind, n.under = convKind, u
+			return n, true
+		}
+	}
+	return n, false
+}
+
+// method checks whether the function is a method with pointer receiver,
+// the requested name, a single parameter of the requested type,
+// and a single result of error type.
+func method(f reflect.Func, name, param string) bool {
+	return f.Name == name && f.Recv != nil && f.Recv.Type.Star &&
+		len(f.Params) == 1 && f.Params[0].Type.String() == param &&
+		len(f.Results) == 1 && f.Results[0].Type.String() == "error"
+}
+
+// structure returns a local structure the type refers to.
+func (b Binder) structure(t *reflect.Type) (*reflect.Struct, bool) {
+	if b.Pkg == nil {
+		return nil, false
+	}
+	return b.Pkg.Struct(t)
+}
+
+// packages is a cache of the packages that are parsed by LoadPackage.
+// Nil values mean the packages cannot be found.
+var packages = map[string]*reflect.Package{}
+
+// LoadPackage finds a package by its import path, parses it,
+// and returns it and true. Parsed packages are cached.
+// If the package cannot be found or parsed, nil and false are returned.
+func LoadPackage(imp string) (*reflect.Package, bool) {
+	if p, ok := packages[imp]; ok {
+		return p, p != nil
+	}
+	packages[imp] = nil
+	dir, err := modpath.ToPath(imp)
+	if err != nil {
+		return nil, false
+	}
+	if fs, _ := filepath.Glob(filepath.Join(dir, "*.go")); len(fs) == 0 {
+		return nil, false
+	}
+	p, err := reflect.ParseDir(dir, false)
+	if err != nil {
+		return nil, false
+	}
+	packages[imp] = p
+	return p, true
+}
+
+// key returns a key of the form value the field is bound from
+// and true. If the field must be ignored, false is returned.
+func key(f reflect.Arg) (string, bool) {
+	if !ast.IsExported(name(f)) {
+		return "", false
+	}
+	k := strings.Split(r.StructTag(f.Tag).Get(FormTag), ",")[0]
+	switch k {
+	case "-":
+		return "", false
+	case "":
+		return name(f), true
+	}
+	return k, true
+}
+
+// name returns a name of the field. Embedded fields
+// are named after their types.
+func name(f reflect.Arg) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.Type.Name
+}
@@ -0,0 +1,204 @@
+package strconv
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/goaltools/goal/internal/reflect"
+)
+
+var binderPkg = &r.Package{
+	Structs: []r.Struct{
+		{
+			Name: "User",
+			Fields: []r.Arg{
+				{Type: &r.Type{Name: "Meta"}},
+				{Name: "Name", Tag: `form:"name"`, Type: &r.Type{Name: "string"}},
+				{Name: "Tags", Type: &r.Type{Name: "[]string"}},
+				{Name: "Address", Tag: `form:"address"`, Type: &r.Type{Name: "Address", Star: true}},
+				{Name: "Ignored", Tag: `form:"-"`, Type: &r.Type{Name: "T", Package: "testing"}},
+				{Name: "private", Type: &r.Type{Name: "T", Package: "testing"}},
+			},
+		},
+		{
+			Name: "Meta",
+			Fields: []r.Arg{
+				{Name: "Token", Tag: `form:"token,omitempty"`, Type: &r.Type{Name: "string"}},
+			},
+		},
+		{
+			Name: "Address",
+			Fields: []r.Arg{
+				{Name: "Zip", Type: &r.Type{Name: "int"}},
+			},
+		},
+		{
+			Name: "Unsupported",
+			Fields: []r.Arg{
+				{Name: "T", Type: &r.Type{Name: "T", Package: "testing"}},
+			},
+		},
+		{
+			Name: "Recursive",
+			Fields: []r.Arg{
+				{Name: "Next", Type: &r.Type{Name: "Recursive", Star: true}},
+			},
+		},
+	},
+}
+
+func TestBinderRender(t *testing.T) {
+	b := Binder{FnMap: load(t), Pkg: binderPkg, PkgName: "contr"}
+	exp := "contr.User{\n" +
+		"Meta: contr.Meta{\n" +
+		`Token: strconv.String(r.Form, "token"),` + "\n" +
+		"},\n" +
+		`Name: strconv.String(r.Form, "name"),` + "\n" +
+		`Tags: strconv.Strings(r.Form, "Tags[]"),` + "\n" +
+		"Address: &contr.Address{\n" +
+		`Zip: strconv.Int(r.Form, "address.Zip"),` + "\n" +
+		"},\n" +
+		"}"
+	res, err := b.Render("strconv", "r.Form", r.Arg{Name: "u", Type: &r.Type{Name: "User"}})
+	if err != nil || res != exp {
+		t.Errorf("Incorrect result of Render. Expected:\n%s\ngot:\n%s\n%v.", exp, res, err)
+	}
+
+	exp = `strconv.Int(r.Form, "page")`
+	if res, err := b.Render("strconv", "r.Form", r.Arg{Name: "page", Type: &r.Type{Name: "int"}}); err != nil || res != exp {
+		t.Errorf("Incorrect result of Render. Expected `%s`, got `%s`, %v.", exp, res, err)
+	}
+}
+
+func TestBinderSupported(t *testing.T) {
+	b := Binder{FnMap: load(t), Pkg: binderPkg}
+	for typ, exp := range map[string]bool{
+		"User":        true,
+		"Address":     true,
+		"Unsupported": false,
+		"Recursive":   false,
+		"Unknown":     false,
+	} {
+		err := b.Supported(r.Arg{Name: "a", Type: &r.Type{Name: typ}})
+		if (err == nil) != exp {
+			t.Errorf(`"%s": expected supported = %v, got error %v.`, typ, exp, err)
+		}
+	}
+
+	b.Pkg = nil
+	if err := b.Supported(r.Arg{Name: "u", Type: &r.Type{Name: "User"}}); err == nil {
+		t.Errorf("Local structures must not be supported if package is not specified.")
+	}
+}
+
+func TestBinderRender_Named(t *testing.T) {
+	method := func(recv, name, param string) r.Func {
+		return r.Func{
+			Name:    name,
+			Recv:    &r.Arg{Type: &r.Type{Name: recv, Star: true}},
+			Params:  []r.Arg{{Type: &r.Type{Name: param}}},
+			Results: []r.Arg{{Type: &r.Type{Name: "error"}}},
+		}
+	}
+	pkg := &r.Package{
+		Imports: r.Imports{"app.go": {"uuid": "github.com/google/uuid"}},
+		Methods: r.Methods{
+			"Slug": {method("Slug", "UnmarshalText", "[]byte")},
+			"Code": {method("Code", "FromString", "string")},
+			"Bad":  {method("Bad", "UnmarshalText", "string")},
+		},
+		Types: r.Types{
+			"UserID": {Name: "int64"},
+			"Names":  {Name: "[]string"},
+			"Slug":   {Name: "string"},
+			"Bad":    {Name: "map[string]string"},
+		},
+	}
+	ext := &r.Package{
+		Methods: r.Methods{"UUID": {method("UUID", "UnmarshalText", "[]byte")}},
+	}
+	b := Binder{
+		FnMap:   load(t),
+		Pkg:     pkg,
+		PkgName: "contr",
+		File:    "app.go",
+		Load: func(imp string) (*r.Package, bool) {
+			return ext, imp == "github.com/google/uuid"
+		},
+		Imports: map[string]string{"github.com/google/uuid": "i0"},
+	}
+	for _, v := range []struct {
+		typ  r.Type
+		exp  string
+		deps []string
+	}{
+		{r.Type{Name: "UserID"}, `contr.UserID(strconv.Int64(r.Form, "a"))`, []string{}},
+		{r.Type{Name: "Names"}, `contr.Names(strconv.Strings(r.Form, "a[]"))`, []string{}},
+		{r.Type{Name: "Slug"}, `func() (v contr.Slug) { strconv.Text(r.Form, "a", &v); return }()`, []string{}},
+		{r.Type{Name: "Code"}, `func() (v contr.Code) { strconv.FromString(r.Form, "a", &v); return }()`, []string{}},
+		{
+			r.Type{Name: "UUID", Package: "uuid"},
+			`func() (v i0.UUID) { strconv.Text(r.Form, "a", &v); return }()`,
+			[]string{"github.com/google/uuid"},
+		},
+	} {
+		a := r.Arg{Name: "a", Type: &v.typ}
+		if err := b.Supported(a); err != nil {
+			t.Errorf(`"%s" is expected to be supported, got %v.`, v.typ.String(), err)
+		}
+		if res, err := b.Render("strconv", "r.Form", a); err != nil || res != v.exp {
+			t.Errorf("Incorrect result of Render. Expected `%s`, got `%s`, %v.", v.exp, res, err)
+		}
+		if deps := b.Deps(a); !reflect.DeepEqual(deps, v.deps) {
+			t.Errorf(`Incorrect dependencies of "%s". Expected %v, got %v.`, v.typ.String(), v.deps, deps)
+		}
+	}
+
+	for _, typ := range []r.Type{
+		{Name: "Bad"},
+		{Name: "UserID", Star: true},
+		{Name: "UUID", Package: "unknown"},
+	} {
+		if err := b.Supported(r.Arg{Name: "a", Type: &typ}); err == nil {
+			t.Errorf(`"%s" is expected to be unsupported.`, typ.String())
+		}
+	}
+}
+
+func TestBinderRender_Layout(t *testing.T) {
+	pkg := &r.Package{
+		Structs: []r.Struct{
+			{
+				Name: "Period",
+				Fields: []r.Arg{
+					{Name: "Since", Tag: `layout:"2006-01"`, Type: &r.Type{Name: "Time", Package: "time"}},
+					{Name: "Dates", Type: &r.Type{Name: "[]Time", Package: "time"}},
+				},
+			},
+		},
+	}
+	f := &r.Func{
+		Comments: []string{"//@layout from Jan 2, 2006", "//@layout dates 02.01.2006", "//@layout Since"},
+	}
+	b := Binder{FnMap: load(t), Pkg: pkg, PkgName: "contr"}.For(f)
+	for _, v := range []struct {
+		arg r.Arg
+		exp string
+	}{
+		{r.Arg{Name: "from", Type: &r.Type{Name: "Time", Package: "time"}}, `strconv.TimeLayout(r.Form, "from", "Jan 2, 2006")`},
+		{r.Arg{Name: "to", Type: &r.Type{Name: "Time", Package: "time"}}, `strconv.Time(r.Form, "to")`},
+		{r.Arg{Name: "dates", Type: &r.Type{Name: "[]Time", Package: "time"}}, `strconv.TimesLayout(r.Form, "dates[]", "02.01.2006")`},
+		{r.Arg{Name: "every", Type: &r.Type{Name: "Duration", Package: "time"}}, `strconv.Duration(r.Form, "every")`},
+		{
+			r.Arg{Name: "p", Type: &r.Type{Name: "Period"}},
+			"contr.Period{\n" +
+				`Since: strconv.TimeLayout(r.Form, "Since", "2006-01"),` + "\n" +
+				`Dates: strconv.Times(r.Form, "Dates[]"),` + "\n" +
+				"}",
+		},
+	} {
+		if res, err := b.Render("strconv", "r.Form", v.arg); err != nil || res != v.exp {
+			t.Errorf("Incorrect result of Render. Expected `%s`, got `%s`, %v.", v.exp, res, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package strconv
+
+import (
+	"fmt"
+	r "reflect"
+	"strings"
+
+	"github.com/goaltools/goal/internal/reflect"
+	"github.com/goaltools/goal/validation"
+)
+
+// ValidateTag is a name of the struct field tag that defines
+// validation rules of a field, e.g.:
+//
+//	Name string `form:"name" validate:"required max=64"`
+//
+// Rules of action parameters are defined using DirectiveValidate.
+const ValidateTag = "validate"
+
+// DirectiveValidate is a name of the comment directive that defines
+// validation rules of a parameter, e.g.:
+//
+//	//@validate page min=1 max=100
+const DirectiveValidate = "validate"
+
+// numbers are types whose values are limited by "min" and "max" rules.
+var numbers = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "byte": true, "rune": true,
+}
+
+// Checks returns code that validates the argument using the rules
+// defined by DirectiveValidate comments of the function (see For)
+// and "validate" tags of the fields of structures. Every element
+// of the result is a call of validation.Check, e.g.:
+//
+//	validation.Check(r.Form, "page", validation.Number, "min=1", "max=100")
+//
+// An error is returned if the rules are incorrect or
+// not supported by the type of the argument.
+func (b Binder) Checks(pkgName, vsName string, a reflect.Arg) ([]string, error) {
+	res := []string{}
+	err := b.checks(pkgName, vsName, "", a, b.Rules[a.Name], &res, map[string]bool{})
+	return res, err
+}
+
+// checks is an implementation of Checks. Found checks of the argument whose key
+// is prefixed with the requested prefix are added to the res.
+func (b Binder) checks(pkgName, vsName, prefix string, a reflect.Arg, rules []string, res *[]string, seen map[string]bool) error {
+	s, ok := b.structure(a.Type)
+	if !ok {
+		if len(rules) == 0 {
+			return nil
+		}
+		k, kind := b.kind(prefix+a.Name, a.Type)
+		for _, rule := range rules {
+			v, err := validation.ParseRule(rule)
+			if err != nil {
+				return fmt.Errorf(`argument "%s": %v`, prefix+a.Name, err)
+			}
+			if v.Name != validation.Required && kind == "Other" {
+				return fmt.Errorf(`argument "%s" of type "%s" does not support "%s" rule`, prefix+a.Name, a.Type, v.Name)
+			}
+		}
+		*res = append(*res, fmt.Sprintf(`%s.Check(%s, "%s", %s.%s, "%s")`,
+			pkgName, vsName, k, pkgName, kind, strings.Join(rules, `", "`)))
+		return nil
+	}
+	if len(rules) > 0 {
+		return fmt.Errorf(`argument "%s" is a structure, use "%s" tags of its fields instead`, prefix+a.Name, ValidateTag)
+	}
+	if seen[s.Name] {
+		return nil
+	}
+	seen[s.Name] = true
+	defer delete(seen, s.Name)
+	for _, f := range s.Fields {
+		k, ok := key(f)
+		if !ok {
+			continue
+		}
+		rs := strings.Fields(r.StructTag(f.Tag).Get(ValidateTag))
+		if _, ok := b.structure(f.Type); ok {
+			// Keys are prefixed the same way Render does.
+			p := prefix
+			if f.Name != "" {
+				p += k + "."
+			}
+			if err := b.In(s.File).checks(pkgName, vsName, p, f, rs, res, seen); err != nil {
+				return fmt.Errorf(`field "%s" of "%s": %v`, name(f), s.Name, err)
+			}
+			continue
+		}
+		arg := reflect.Arg{Name: k, Type: f.Type}
+		if err := b.In(s.File).checks(pkgName, vsName, prefix, arg, rs, res, seen); err != nil {
+			return fmt.Errorf(`field "%s" of "%s": %v`, name(f), s.Name, err)
+		}
+	}
+	return nil
+}
+
+// kind returns a key the value of the requested type is bound from
+// and a name of the validation.Kind constant that must be used
+// for its checks.
+func (b Binder) kind(k string, t *reflect.Type) (string, string) {
+	s := t.String()
+	if _, ok := b.FnMap[s]; !ok {
+		n, ok := b.named(t)
+		if !ok || n.kind != convKind {
+			return k, "Other"
+		}
+		s = n.under.String()
+	}
+	switch {
+	case strings.HasPrefix(s, "[]"):
+		return k + "[]", "List"
+	case s == "string":
+		return k, "String"
+	case numbers[s]:
+		return k, "Number"
+	}
+	return k, "Other"
+}
@@ -0,0 +1,71 @@
+package strconv
+
+import (
+	"reflect"
+	"testing"
+
+	r "github.com/goaltools/goal/internal/reflect"
+)
+
+func TestBinderChecks(t *testing.T) {
+	pkg := &r.Package{
+		Structs: []r.Struct{
+			{
+				Name: "Form",
+				Fields: []r.Arg{
+					{Name: "Name", Tag: `form:"name" validate:"required max=64"`, Type: &r.Type{Name: "string"}},
+					{Name: "Tags", Tag: `validate:"max=5"`, Type: &r.Type{Name: "[]string"}},
+					{Name: "Address", Tag: `form:"address"`, Type: &r.Type{Name: "Address", Star: true}},
+					{Name: "Note", Type: &r.Type{Name: "string"}},
+				},
+			},
+			{
+				Name: "Address",
+				Fields: []r.Arg{
+					{Name: "Zip", Tag: `validate:"min=10000"`, Type: &r.Type{Name: "int"}},
+				},
+			},
+		},
+		Types: r.Types{
+			"UserID": &r.Type{Name: "int64"},
+		},
+	}
+	f := &r.Func{
+		Comments: []string{"//@validate page min=1 max=100", "//@validate id required", "//@validate id min=1", "//@validate t required"},
+	}
+	b := Binder{FnMap: load(t), Pkg: pkg, PkgName: "contr"}.For(f)
+	for _, v := range []struct {
+		arg r.Arg
+		exp []string
+	}{
+		{r.Arg{Name: "page", Type: &r.Type{Name: "int"}}, []string{`validation.Check(r.Form, "page", validation.Number, "min=1", "max=100")`}},
+		{r.Arg{Name: "id", Type: &r.Type{Name: "UserID"}}, []string{`validation.Check(r.Form, "id", validation.Number, "required", "min=1")`}},
+		{r.Arg{Name: "t", Type: &r.Type{Name: "Time", Package: "time"}}, []string{`validation.Check(r.Form, "t", validation.Other, "required")`}},
+		{r.Arg{Name: "q", Type: &r.Type{Name: "string"}}, []string{}},
+		{
+			r.Arg{Name: "f", Type: &r.Type{Name: "Form"}},
+			[]string{
+				`validation.Check(r.Form, "name", validation.String, "required", "max=64")`,
+				`validation.Check(r.Form, "Tags[]", validation.List, "max=5")`,
+				`validation.Check(r.Form, "address.Zip", validation.Number, "min=10000")`,
+			},
+		},
+	} {
+		if res, err := b.Checks("validation", "r.Form", v.arg); err != nil || !reflect.DeepEqual(res, v.exp) {
+			t.Errorf("Incorrect result of Checks. Expected %#v, got %#v, %v.", v.exp, res, err)
+		}
+	}
+
+	for _, c := range []string{"//@validate a pattern=.*", "//@validate a min=x", "//@validate b min=1", "//@validate f required"} {
+		b := Binder{FnMap: load(t), Pkg: pkg, PkgName: "contr"}.For(&r.Func{Comments: []string{c}})
+		for _, a := range []r.Arg{
+			{Name: "a", Type: &r.Type{Name: "int"}},
+			{Name: "b", Type: &r.Type{Name: "Time", Package: "time"}},
+			{Name: "f", Type: &r.Type{Name: "Form"}},
+		} {
+			if _, err := b.Checks("validation", "r.Form", a); err == nil && len(b.Rules[a.Name]) > 0 {
+				t.Errorf(`"%s": error expected.`, c)
+			}
+		}
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"os"
 
+	"github.com/goaltools/goal/internal/modpath"
 	"github.com/goaltools/goal/internal/reflect"
-
-	"github.com/conveyer/importpath"
 )
 
+// Import is an import path of the package with conversion functions.
+const Import = "github.com/goaltools/goal/strconv"
+
 // ErrUnsupportedType is an error that indicates that there is no conversion
 // function for the requested type.
 var ErrUnsupportedType = errors.New("unsupported type")
@@ -44,24 +47,44 @@ func (m FnMap) Render(pkgName, vsName string, a reflect.Arg) (string, error) {
 	return fmt.Sprintf(`%s.%s(%s, "%s")`, pkgName, f.Name, vsName, n), nil
 }
 
-// Context returns mappings between types that can be parsed using
+// Load returns mappings between types that can be parsed using
 // strconv package and functions for that conversions.
 // All conversion functions meet the following criteria:
 // 1. They are exported.
 // 2. They expect 3 arguments: url.Values, string, ...int.
 // 3. They return 1 argument.
 // This is useful for code generation.
-func Context() FnMap {
-	p, _ := importpath.ToPath("github.com/goaltools/goal/strconv")
+// An error is returned if the strconv package cannot be found or parsed.
+func Load() (FnMap, error) {
+	p, err := Path()
+	if err != nil {
+		return nil, err
+	}
 	fs := FnMap{}
-	pkg := reflect.ParseDir(p, false)
+	pkg, err := reflect.ParseDir(p, false)
+	if err != nil {
+		return nil, err
+	}
 	for i := range pkg.Funcs {
 		if !strconvFunc(pkg.Funcs[i]) {
 			continue
 		}
 		fs[pkg.Funcs[i].Results[0].Type.String()] = pkg.Funcs[i]
 	}
-	return fs
+	return fs, nil
+}
+
+// Path returns a directory of the strconv package
+// or an error if it does not exist.
+func Path() (string, error) {
+	p, err := modpath.ToPath(Import)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(p); err != nil {
+		return "", fmt.Errorf(`cannot find package "%s": %v`, Import, err)
+	}
+	return p, nil
 }
 
 // strconvFunc gets a reflect.Func and detects whether it is
@@ -7,7 +7,7 @@ import (
 )
 
 func TestRender(t *testing.T) {
-	c := Context()
+	c := load(t)
 	a := r.Arg{Name: "names", Type: &r.Type{Name: "[]string"}}
 	exp := `strconv.Strings(r.Form, "names[]")`
 	var expErr error
@@ -23,22 +23,24 @@ func TestRender(t *testing.T) {
 	}
 }
 
-func TestContext(t *testing.T) {
-	c := Context()
+func TestLoad(t *testing.T) {
+	c := load(t)
 	supportedTypes := []string{
 		"bool", "string", "int", "int8", "int16", "int32", "int64",
 		"float32", "float64", "uint", "uint8", "uint16", "uint32", "uint64",
 
 		"[]bool", "[]string", "[]int", "[]int8", "[]int16", "[]int32", "[]int64",
 		"[]float32", "[]float64", "[]uint", "[]uint8", "[]uint16", "[]uint32", "[]uint64",
+
+		"time.Time", "[]time.Time", "time.Duration", "[]time.Duration",
 	}
 	num := len(supportedTypes)
 	if l := len(c); l != num {
-		t.Errorf("Context returns incorrect number of arguments. Expected %d, got %d.", num, l)
+		t.Errorf("Load returns incorrect number of arguments. Expected %d, got %d.", num, l)
 	}
 	for _, k := range supportedTypes {
 		if _, ok := c[k]; !ok {
-			t.Errorf(`Incorrect result of Context. Key "%s" is not found in %v.`, k, c)
+			t.Errorf(`Incorrect result of Load. Key "%s" is not found in %v.`, k, c)
 		}
 	}
 }
@@ -83,3 +85,18 @@ func TestStrconvFunc(t *testing.T) {
 }
 
 var errMsg = `Incorrect result. Expected "%v", got "%v".`
+
+func TestPath(t *testing.T) {
+	if _, err := Path(); err != nil {
+		t.Errorf(`Path of "%s" is expected to be resolved, got error "%v".`, Import, err)
+	}
+}
+
+// load returns the mappings or stops the test if they cannot be loaded.
+func load(t *testing.T) FnMap {
+	fs, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
@@ -9,41 +9,83 @@ import (
 
 	"github.com/goaltools/goal/internal/log"
 	"github.com/goaltools/goal/tools/create"
+	"github.com/goaltools/goal/tools/env"
 	"github.com/goaltools/goal/tools/generate/handlers"
 	"github.com/goaltools/goal/tools/run"
 	"github.com/goaltools/goal/utils/tool"
 )
 
-var trace = flag.Bool("trace", false, "show stack trace in case of runtime errors")
+var (
+	trace     = flag.Bool("trace", false, "show stack trace in case of runtime errors")
+	verbose   = flag.Bool("v", false, "show trace messages in addition to the regular ones")
+	quiet     = flag.Bool("q", false, "show warnings and errors only")
+	noColor   = flag.Bool("no-color", false, "do not use colors even if the output is a terminal")
+	logFormat = flag.String("log-format", "text", `format of the log messages: "text" or "json"`)
+)
 
 // tools stores information about the registered subcommands (tools)
 // the toolkit supports.
 var tools = tool.NewContext(
 	create.Handler,
 	run.Handler,
+	env.Handler,
 
 	handlers.Handler,
 )
 
 func main() {
-	// Do not show stacktrace if something goes wrong
-	// but tracing is disabled.
-	defer func() {
-		if err := recover(); err != nil {
-			if *trace {
-				log.Warn.Fatalf("TRACE: %v.", err)
-			}
-			os.Exit(0)
-		}
-	}()
-
 	// Try to run the command user requested.
 	// Ignoring the first argument as it is name of the executable.
 	flag.Parse()
-	err := tools.Run(flag.Args())
-	if err != nil {
+	err := configureLog()
+	if err == nil {
+		err = tools.Run(flag.Args())
+	}
+	if err == nil {
+		return
+	}
+
+	// Show the error and terminate with an appropriate exit code.
+	// Do not show stacktrace if something goes wrong
+	// but tracing is disabled.
+	code := tool.ExitCode(err)
+	switch code {
+	case tool.ExitUsage:
 		log.Warn.Printf(unknownCmd, err, os.Args[0])
+	default:
+		log.Error.Printf("Error: %v.", err)
+	}
+	if e, ok := err.(*tool.Error); ok && *trace && len(e.Stack) > 0 {
+		log.Warn.Printf("TRACE: %s", e.Stack)
+	}
+	os.Exit(code)
+}
+
+// configureLog sets up the default loggers using the global flags.
+// NO_COLOR environment variable disables colors, too.
+func configureLog() error {
+	c := log.Config{
+		Level: log.LevelInfo,
+		Color: !*noColor && os.Getenv("NO_COLOR") == "",
+		Tool:  tools.Command(flag.Args()),
+	}
+	switch {
+	case *verbose && *quiet:
+		return tool.UsageError("flags -v and -q cannot be used together")
+	case *verbose:
+		c.Level = log.LevelTrace
+	case *quiet:
+		c.Level = log.LevelWarn
+	}
+	switch *logFormat {
+	case "text":
+	case "json":
+		c.JSON = true
+	default:
+		return tool.UsageError(`unsupported log format "%s", expected "text" or "json"`, *logFormat)
 	}
+	log.Configure(c)
+	return nil
 }
 
 var unknownCmd = `Error: %v.
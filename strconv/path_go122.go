@@ -0,0 +1,14 @@
+//go:build go1.22
+// +build go1.22
+
+package strconv
+
+import (
+	"net/http"
+)
+
+// pathValue returns the value of the wildcard of the request's path
+// matched by http.ServeMux or an empty string if there is no such wildcard.
+func pathValue(r *http.Request, k string) string {
+	return r.PathValue(k)
+}
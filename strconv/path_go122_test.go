@@ -0,0 +1,25 @@
+//go:build go1.22
+// +build go1.22
+
+// Patterns with wildcards are matched by the new ServeMux only.
+//go:debug httpmuxgo121=0
+
+package strconv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPath_ServeMux(t *testing.T) {
+	id := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id = Int(Path(r, "id"), "id")
+	})
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/7?id=2", nil))
+	if id != 7 {
+		t.Errorf("Expected the wildcard of the path 7, got %d.", id)
+	}
+}
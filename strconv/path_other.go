@@ -0,0 +1,14 @@
+//go:build !go1.22
+// +build !go1.22
+
+package strconv
+
+import (
+	"net/http"
+)
+
+// pathValue returns an empty string as http.ServeMux of the older
+// versions of Go does not match wildcards of the request's path.
+func pathValue(r *http.Request, k string) string {
+	return ""
+}
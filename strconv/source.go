@@ -0,0 +1,70 @@
+package strconv
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+/*
+	Below are functions that return parts of a request as url.Values,
+	so the values can be parsed by the functions above.
+*/
+
+// Header returns headers of the request. Their keys are canonical,
+// e.g. "X-Auth-Token".
+func Header(r *http.Request) url.Values {
+	return url.Values(r.Header)
+}
+
+// Cookies returns values of the cookies of the request.
+func Cookies(r *http.Request) url.Values {
+	vs := url.Values{}
+	for _, c := range r.Cookies() {
+		vs.Add(c.Name, c.Value)
+	}
+	return vs
+}
+
+// pathKey is a key of the request's context
+// parameters of the request's path are stored by.
+type pathKey struct{}
+
+// WithPath returns a shallow copy of the request whose context stores
+// the parameters of the request's path. Routers or middleware that
+// adapt them are expected to use it, so the parameters can be read by Path.
+func WithPath(r *http.Request, vs url.Values) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), pathKey{}, vs))
+}
+
+// Path returns values of the requested parameters of the request's path.
+// The parameters stored by WithPath are used. If a parameter is not
+// among them, the value of the wildcard matched by http.ServeMux is used
+// (Go 1.22 or newer is required). The values are available by the keys
+// with "[]" suffix, too, so slices can be parsed.
+func Path(r *http.Request, keys ...string) url.Values {
+	ps, _ := r.Context().Value(pathKey{}).(url.Values)
+	vs := url.Values{}
+	for _, k := range keys {
+		v, ok := ps[k]
+		if !ok {
+			if s := pathValue(r, k); s != "" {
+				v = []string{s}
+			}
+		}
+		if len(v) > 0 {
+			vs[k], vs[k+"[]"] = v, v
+		}
+	}
+	return vs
+}
+
+// Rename returns values of the key as url.Values with the requested name.
+// The values are available by the name with "[]" suffix, too,
+// so slices can be parsed.
+func Rename(vs url.Values, k, name string) url.Values {
+	return url.Values{
+		name:        vs[k],
+		name + "[]": vs[k],
+	}
+}
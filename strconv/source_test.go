@@ -0,0 +1,41 @@
+package strconv
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestHeader_Cookies(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("x-auth-token", "secret")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "123"})
+
+	if v := String(Header(r), "X-Auth-Token"); v != "secret" {
+		t.Errorf(`Expected header "secret", got "%s".`, v)
+	}
+	if v := Int(Cookies(r), "session"); v != 123 {
+		t.Errorf(`Expected cookie 123, got %d.`, v)
+	}
+}
+
+func TestPath(t *testing.T) {
+	r, _ := http.NewRequest("GET", "/users/1?id=2&page=3", nil)
+	r = WithPath(r, url.Values{"id": {"1"}, "name": {"john"}})
+
+	exp := url.Values{"id": {"1"}, "id[]": {"1"}}
+	if vs := Path(r, "id", "page"); !reflect.DeepEqual(vs, exp) {
+		t.Errorf("Expected %#v, got %#v.", exp, vs)
+	}
+}
+
+func TestRename(t *testing.T) {
+	vs := Rename(url.Values{"X-Ids": {"1", "2"}}, "X-Ids", "ids")
+	if v := Ints(vs, "ids[]"); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v.", v)
+	}
+	if v := Int(vs, "ids"); v != 2 {
+		t.Errorf("Expected 2, got %d.", v)
+	}
+}
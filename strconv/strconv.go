@@ -1,12 +1,15 @@
 // Package strconv implements conversions from string representation.
-// All functions here require url.Values, a key, and an optional index;
-// and return their appropriate types.
+// All conversion functions require url.Values, a key, and an optional index;
+// and return their appropriate types. Headers, cookies, and other
+// parts of a request are available as url.Values, too.
 package strconv
 
 import (
+	"encoding"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 /*
@@ -230,6 +233,101 @@ func Float64s(vs url.Values, k string, is ...int) (as []float64) {
 	return
 }
 
+/*
+	Below are functions for parsing time.
+*/
+
+// TimeLayouts are layouts that are tried in order by Time.
+// Unix timestamps (the number of seconds since January 1, 1970 UTC)
+// are supported, too.
+var TimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// Time parses the string using TimeLayouts. If none of them matches
+// and the string is an integer, it is treated as a Unix timestamp.
+// If it is impossible to parse the string, zero time is returned.
+func Time(vs url.Values, k string, is ...int) time.Time {
+	s := get(vs, k, is)
+	for _, l := range TimeLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(n, 0).UTC()
+	}
+	return time.Time{}
+}
+
+// Times returns a slice of time values represented by a slice of strings.
+func Times(vs url.Values, k string, is ...int) (as []time.Time) {
+	for i := range vs[k] {
+		as = append(as, Time(vs, k, i))
+	}
+	return
+}
+
+// TimeLayout is similar to Time but parses the string using
+// the requested layout, e.g. "02.01.2006".
+func TimeLayout(vs url.Values, k, layout string, is ...int) time.Time {
+	t, _ := time.Parse(layout, get(vs, k, is))
+	return t
+}
+
+// TimesLayout is similar to Times but parses the strings using
+// the requested layout.
+func TimesLayout(vs url.Values, k, layout string, is ...int) (as []time.Time) {
+	for i := range vs[k] {
+		as = append(as, TimeLayout(vs, k, layout, i))
+	}
+	return
+}
+
+// Duration parses a duration string such as "300ms" or "1h30m".
+// If it is impossible to parse the string, 0 is returned.
+func Duration(vs url.Values, k string, is ...int) time.Duration {
+	d, _ := time.ParseDuration(get(vs, k, is))
+	return d
+}
+
+// Durations returns a slice of durations represented by a slice of strings.
+func Durations(vs url.Values, k string, is ...int) (as []time.Duration) {
+	for i := range vs[k] {
+		as = append(as, Duration(vs, k, i))
+	}
+	return
+}
+
+/*
+	Below are functions for parsing custom types.
+*/
+
+// FromStringer is implemented by types that can parse
+// their string representation.
+type FromStringer interface {
+	FromString(s string) error
+}
+
+// Text unmarshals the value into v using its UnmarshalText method.
+// If the key is not found, v is not changed and nil is returned.
+func Text(vs url.Values, k string, v encoding.TextUnmarshaler, is ...int) error {
+	if _, ok := vs[k]; !ok {
+		return nil
+	}
+	return v.UnmarshalText([]byte(get(vs, k, is)))
+}
+
+// FromString is similar to Text but for types that implement FromStringer.
+func FromString(vs url.Values, k string, v FromStringer, is ...int) error {
+	if _, ok := vs[k]; !ok {
+		return nil
+	}
+	return v.FromString(get(vs, k, is))
+}
+
 /*
 	Below are various helper functions.
 */
@@ -1,9 +1,12 @@
 package strconv
 
 import (
+	"errors"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 /*
@@ -298,6 +301,90 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestTime(t *testing.T) {
+	vs := url.Values{
+		"t": {
+			"2015-06-01T10:30:00+03:00", "2015-06-01T10:30:00", "2015-06-01",
+			"1433154600", "xxx", "01.06.2015",
+		},
+	}
+	exp := []time.Time{
+		time.Date(2015, 6, 1, 10, 30, 0, 0, time.FixedZone("", 3*60*60)),
+		time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC),
+		time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2015, 6, 1, 10, 30, 0, 0, time.UTC),
+		{},
+		{},
+	}
+	rs := Times(vs, "t")
+	if len(rs) != len(exp) {
+		t.Fatalf(errMsg, exp, rs)
+	}
+	for i := range exp {
+		if !rs[i].Equal(exp[i]) {
+			t.Errorf(errMsg, exp[i], rs[i])
+		}
+	}
+
+	e := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+	if r := TimeLayout(vs, "t", "02.01.2006"); !r.Equal(e) {
+		t.Errorf(errMsg, e, r)
+	}
+	if r := TimesLayout(vs, "t", "02.01.2006"); len(r) != 6 || !r[5].Equal(e) || !r[0].IsZero() {
+		t.Errorf(errMsg, e, r)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	vs := url.Values{
+		"d": {"1h30m", "300ms", "xxx"},
+	}
+	exp := []time.Duration{90 * time.Minute, 300 * time.Millisecond, 0}
+	if r := Durations(vs, "d"); !reflect.DeepEqual(r, exp) {
+		t.Errorf(errMsg, exp, r)
+	}
+	if r := Duration(vs, "d", 1); r != exp[1] {
+		t.Errorf(errMsg, exp[1], r)
+	}
+}
+
+type upper string
+
+func (u *upper) UnmarshalText(t []byte) error {
+	if len(t) == 0 {
+		return errors.New("empty")
+	}
+	*u = upper(strings.ToUpper(string(t)))
+	return nil
+}
+
+func (u *upper) FromString(s string) error {
+	return u.UnmarshalText([]byte(s))
+}
+
+func TestText(t *testing.T) {
+	var u upper
+	if err := Text(vs, "s", &u, 1); err != nil || u != "Y" {
+		t.Errorf(errMsg, "Y", u)
+	}
+	if err := Text(url.Values{"s": {""}}, "s", &u); err == nil {
+		t.Errorf("Error of UnmarshalText expected.")
+	}
+	if err := Text(vs, "unknown", &u); err != nil || u != "Y" {
+		t.Errorf("Missing keys must be ignored, got %v, %v.", u, err)
+	}
+}
+
+func TestFromString(t *testing.T) {
+	var u upper
+	if err := FromString(vs, "s", &u); err != nil || u != "Z" {
+		t.Errorf(errMsg, "Z", u)
+	}
+	if err := FromString(vs, "unknown", &u); err != nil || u != "Z" {
+		t.Errorf("Missing keys must be ignored, got %v, %v.", u, err)
+	}
+}
+
 var vs = url.Values{
 	"b": {"t", "false", "0", "yes", "f"},
 	"f": {"1.1", "2.2"},
@@ -5,22 +5,31 @@
 package create
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/goaltools/goal/internal/log"
+	"github.com/goaltools/goal/internal/modpath"
 	"github.com/goaltools/goal/utils/tool"
 
 	"github.com/conveyer/importpath"
 )
 
+// SkeletonImport is an import path of the skeleton application
+// that is copied to the requested destination.
+const SkeletonImport = "github.com/goaltools/goal/internal/skeleton"
+
 // Handler is an instance of "new" subcommand (tool).
 var Handler = tool.Handler{
 	Run: main,
 
-	Name:  "new",
-	Usage: "{path}",
-	Info:  "create a skeleton application",
+	Name:    "new",
+	Aliases: []string{"n"},
+	Usage:   "[flags] {path}",
+	Info:    "create a skeleton application",
 	Desc: `New creates files and directories to get a new app running quickly.
 The created files and directories will be saved to the specified path.
 
@@ -30,52 +39,63 @@ The path must be a directory that does not exist yet, e.g:
 or alternatively:
 	github.com/MyUsername/ProjectName
 
-Moreover, it is required to be located inside "$GOPATH/src".
+If the path is not inside of an existing Go module, a new module is created:
+the project gets its own go.mod file. Its module path is the import path
+of the project, the one of --module flag, or the name of the directory.
+In GOPATH mode (GO111MODULE=off), the path is required to be located
+inside "$GOPATH/src".
 
 Examples:
 	cli new github.com/goaltools/sample
 	cli new ./sample
-	cli new ../goaltools/sample
+	cli new --module github.com/goaltools/sample ../sample
 `,
 }
 
+var modulePath *string
+
+// goVersion is a version of Go that is written to go.mod files.
+const goVersion = "1.16"
+
 // Main is an entry point of the subcommand (tool).
-func main(hs []tool.Handler, i int, args tool.Data) {
+func main(hs []tool.Handler, i int, args tool.Data) error {
 	// The first argument in the list is a path.
 	// If it's missing use an empty string instead.
 	p := args.GetDefault(0, "")
 
 	// Prepare source and destination directory paths.
-	src, err := importpath.ToPath("github.com/goaltools/goal/internal/skeleton")
-	if err != nil {
-		log.Error.Panic(err)
-	}
-	destImp, err := importpath.Clean(p)
+	src, err := modpath.ToPath(SkeletonImport)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
-	dest, err := importpath.ToPath(destImp)
+	destImp, dest, module, err := destination(p)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
 
 	// Make sure the requested import path (dest) does not exist yet.
 	if _, err := os.Stat(dest); !os.IsNotExist(err) {
-		log.Error.Panicf(`Cannot use "%s", such import path already exists.`, destImp)
+		return fmt.Errorf(`cannot use "%s", such import path already exists`, destImp)
 	}
 
 	// Scan the skeleton directory and get a list of directories / files
 	// to be copied / processed.
 	res, err := walk(src)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
+	}
+
+	// Dependencies of a module are managed by go.mod rather than
+	// the vendor directory.
+	if module {
+		res.exclude("vendor")
 	}
 
 	// Create the directories in destination path.
 	for i := 0; i < len(res.dirs); i++ {
 		err = os.MkdirAll(filepath.Join(dest, res.dirs[i]), 0755)
 		if err != nil {
-			log.Error.Panic(err)
+			return err
 		}
 	}
 
@@ -89,19 +109,104 @@ func main(hs []tool.Handler, i int, args tool.Data) {
 		copyModifiedFile(
 			res.srcs[i].absolute, filepath.Join(dest, res.srcs[i].relative), [][][]byte{
 				{
-					[]byte("github.com/goaltools/goal/internal/skeleton"),
+					[]byte(SkeletonImport),
 					[]byte(destImp),
 				},
 			},
 		)
 	}
 
-	log.Info.Printf(info, destImp)
+	// The project can be started by its import path in GOPATH mode only,
+	// otherwise go.mod of the current directory is used to resolve it.
+	run := destImp
+	if module {
+		if err := ioutil.WriteFile(filepath.Join(dest, modpath.FileName), goMod(destImp), 0644); err != nil {
+			return err
+		}
+		run = dest
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, dest); err == nil && !strings.HasPrefix(rel, "..") {
+				run = "." + string(filepath.Separator) + rel
+			}
+		}
+	}
+
+	log.Info.Printf(info, destImp, run)
+	return nil
+}
+
+// destination gets a path that is requested by user and returns
+// an import path and a directory of the new project. Moreover, it returns
+// true if the project must be created as a new module.
+func destination(p string) (imp, dir string, module bool, err error) {
+	// GOPATH mode: the project must be inside "$GOPATH/src".
+	if !modpath.Enabled() {
+		if imp, err = importpath.Clean(p); err != nil {
+			return
+		}
+		dir, err = importpath.ToPath(imp)
+		return
+	}
+
+	// The project is a part of an existing module.
+	local := strings.HasPrefix(filepath.ToSlash(p), ".") || filepath.IsAbs(p)
+	if local {
+		dir, err = filepath.Abs(p)
+	} else {
+		dir, err = modpath.ToPath(p)
+	}
+	if err == nil {
+		if f, err := modpath.Find(filepath.Dir(dir)); err != nil {
+			return "", "", false, err
+		} else if f != nil {
+			imp, err = modpath.ToImport(dir)
+			return imp, dir, false, err
+		}
+	}
+
+	// Otherwise, a new module is created. An import path is used
+	// as a relative path of the directory if it is not inside of GOPATH.
+	module, err = true, nil
+	dir = filepath.FromSlash(p)
+	if !local {
+		if dir, err = importpath.ToPath(p); err != nil {
+			dir, err = filepath.Base(p), nil
+		}
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return
+	}
+	switch {
+	case *modulePath != "":
+		imp = *modulePath
+	case !local:
+		imp = strings.Trim(filepath.ToSlash(p), "/")
+	default:
+		if imp, err = importpath.ToImport(dir); err != nil {
+			imp, err = filepath.Base(dir), nil
+		}
+	}
+	return
+}
+
+// goMod returns content of a go.mod file of a new module.
+// The version of goal the project is created by is required.
+func goMod(imp string) []byte {
+	s := fmt.Sprintf("module %s\n\ngo %s\n", imp, goVersion)
+	if mod, version, ok := modpath.Own(); ok {
+		s += fmt.Sprintf("\nrequire %s %s\n", mod, version)
+	}
+	return []byte(s)
 }
 
 // Arguments to format are:
-//	[1]: destination app's import path.
+//	[1]: destination app's import path,
+//	[2]: path the app can be started by.
 var info = `Your application "%[1]s" is ready:
 You can run it with:
-	goal run %[1]s
+	goal run %[2]s
 `
+
+func init() {
+	modulePath = Handler.Flags.String("module", "", "module path of the project if a new module is created")
+}
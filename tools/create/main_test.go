@@ -2,8 +2,10 @@ package create
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/goaltools/goal/utils/tool"
@@ -12,18 +14,23 @@ import (
 )
 
 func TestMain_ExistingDir(t *testing.T) {
-	defer expectPanic("Creation of a project in an existing directory should cause a panic.")
-	main(handlers, 0, tool.Data{"./testdata/existingDir"})
+	if err := main(handlers, 0, tool.Data{"./testdata/existingDir"}); err == nil {
+		t.Errorf("Creation of a project in an existing directory should cause an error.")
+	}
 }
 
 func TestMain_ExistingDir_AbsoluteImport(t *testing.T) {
-	defer expectPanic("Creation of a project in an existing directory should cause a panic.")
-	main(handlers, 0, tool.Data{"github.com/goaltools/goal/utils"})
+	if err := main(handlers, 0, tool.Data{"github.com/goaltools/goal/utils"}); err == nil {
+		t.Errorf("Creation of a project in an existing directory should cause an error.")
+	}
 }
 
 func TestStart(t *testing.T) {
 	dst := "./testdata/project"
-	main(handlers, 0, tool.Data{dst})
+	if err := main(handlers, 0, tool.Data{dst}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 
 	rs1, fn1 := walkFunc(dst)
 	filepath.Walk(dst, fn1)
@@ -50,6 +57,49 @@ func TestStart(t *testing.T) {
 	os.RemoveAll(dst)
 }
 
+func TestStart_Module(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goal-create")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	old, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(old)
+	defer os.Setenv("GO111MODULE", os.Getenv("GO111MODULE"))
+	os.Setenv("GO111MODULE", "on")
+
+	if err := main(handlers, 0, tool.Data{"github.com/user/sample"}); err != nil {
+		t.Fatal(err)
+	}
+	d, err := ioutil.ReadFile(filepath.Join(dir, "sample", "go.mod"))
+	if err != nil || !strings.HasPrefix(string(d), "module github.com/user/sample\n") {
+		t.Errorf(`go.mod with the module path expected, got "%s". Error: %v.`, d, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sample", "vendor")); !os.IsNotExist(err) {
+		t.Errorf(`Vendor directory is not expected to be copied to a module.`)
+	}
+	if d, _ := ioutil.ReadFile(filepath.Join(dir, "sample", "main.go")); !strings.Contains(string(d), "github.com/user/sample/") {
+		t.Errorf("Import paths are expected to be replaced, got:\n%s", d)
+	}
+
+	// A project inside of an existing module is a part of it.
+	*modulePath = "example.com/ignored"
+	defer func() {
+		*modulePath = ""
+	}()
+	if err := main(handlers, 0, tool.Data{"./sample/subapp"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sample", "subapp", "go.mod")); !os.IsNotExist(err) {
+		t.Errorf(`New go.mod is not expected inside of an existing module.`)
+	}
+	if d, _ := ioutil.ReadFile(filepath.Join(dir, "sample", "subapp", "main.go")); !strings.Contains(string(d), "github.com/user/sample/subapp/") {
+		t.Errorf("Import path of the module is expected to be used, got:\n%s", d)
+	}
+}
+
 func TestWalkFunc_Error(t *testing.T) {
 	_, fn := walkFunc("")
 	TestError := errors.New("this is a test error")
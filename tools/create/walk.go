@@ -3,6 +3,7 @@ package create
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // sourceFiles contains extensions of files that should be process
@@ -80,3 +81,27 @@ func walkFunc(dest string) (*result, func(string, os.FileInfo, error) error) {
 		return nil
 	}
 }
+
+// exclude removes the directory and its content from the result.
+// The directory is expected to be relative to the scanned one.
+func (r *result) exclude(dir string) {
+	in := func(rel string) bool {
+		return rel == dir || strings.HasPrefix(rel, dir+string(filepath.Separator))
+	}
+	dirs := []string{}
+	for _, d := range r.dirs {
+		if !in(d) {
+			dirs = append(dirs, d)
+		}
+	}
+	r.dirs = dirs
+	for _, ps := range []*[]paths{&r.files, &r.srcs} {
+		res := []paths{}
+		for _, p := range *ps {
+			if !in(p.relative) {
+				res = append(res, p)
+			}
+		}
+		*ps = res
+	}
+}
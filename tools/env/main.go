@@ -0,0 +1,192 @@
+// Package env is used for printing information about
+// how goal resolves import paths, templates, and configuration
+// files on the current machine.
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goaltools/goal/internal/modpath"
+	"github.com/goaltools/goal/internal/strconv"
+	"github.com/goaltools/goal/tools/create"
+	"github.com/goaltools/goal/tools/run"
+	"github.com/goaltools/goal/utils/tool"
+)
+
+// Handler is an instance of "env" subcommand (tool).
+var Handler = tool.Handler{
+	Run: main,
+
+	Name:  "env",
+	Usage: "[flags] [{path}]",
+	Info:  "print information about the environment",
+	Desc: `Env prints how goal resolves things on this machine: GOPATH and
+module root, paths of the skeleton application and strconv package,
+types supported by strconv, configuration file of "goal run", and its variables.
+The path is a project the configuration file is looked up in.
+Current directory is used by-default.
+
+Examples:
+	cli env
+	cli env --json github.com/goaltools/sample
+`,
+}
+
+var jsonOutput *bool
+
+// embedded is shown instead of a path of the handlers template
+// as the default one is a part of the binary.
+const embedded = "(embedded)"
+
+// output is a writer the information is printed to.
+var output io.Writer = os.Stdout
+
+// environment represents information that is printed by the tool.
+// If something cannot be resolved, an error is stored to Errors
+// using the name of the field as a key.
+type environment struct {
+	GOPATH     string `json:"gopath"`
+	GOROOT     string `json:"goroot"`
+	Workdir    string `json:"workdir"`
+	ImportPath string `json:"importPath"`
+
+	ModuleRoot  string `json:"moduleRoot"`
+	ModulePath  string `json:"modulePath"`
+	ModuleCache string `json:"moduleCache"`
+
+	Template     string   `json:"handlersTemplate"`
+	Skeleton     string   `json:"skeleton"`
+	Strconv      string   `json:"strconv"`
+	StrconvTypes []string `json:"strconvTypes"`
+
+	ConfigFile string            `json:"configFile"`
+	Vars       map[string]string `json:"vars"`
+
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// main is an entry point of the "env" subcommand (tool).
+func main(hs []tool.Handler, i int, args tool.Data) error {
+	e := resolve(args.GetDefault(0, "."))
+	if *jsonOutput {
+		enc := json.NewEncoder(output)
+		enc.SetIndent("", "\t")
+		return enc.Encode(e)
+	}
+	e.print(output)
+	return nil
+}
+
+// resolve gets a path of the project and returns information
+// about the environment.
+func resolve(project string) *environment {
+	e := &environment{
+		GOPATH: build.Default.GOPATH,
+		GOROOT: build.Default.GOROOT,
+		Vars:   run.Vars(),
+		Errors: map[string]string{},
+	}
+	e.Workdir, _ = os.Getwd()
+	e.ImportPath = e.catch("ImportPath", func() (string, error) {
+		return modpath.ToImport(".")
+	})
+
+	// Find a module the current directory belongs to.
+	e.ModuleCache = modpath.Cache()
+	if f, err := modpath.Find(e.Workdir); err != nil {
+		e.Errors["ModuleRoot"] = err.Error()
+	} else if f != nil && modpath.Enabled() {
+		e.ModuleRoot, e.ModulePath = f.Dir, f.Path
+	}
+
+	// Resolve paths of the files goal depends on.
+	e.Template = embedded // It can be overridden by --template flag only.
+	e.Skeleton = e.catch("Skeleton", func() (string, error) {
+		return existing(modpath.ToPath(create.SkeletonImport))
+	})
+	e.Strconv = e.catch("Strconv", strconv.Path)
+	if fs, err := strconv.Load(); err == nil {
+		for k := range fs {
+			e.StrconvTypes = append(e.StrconvTypes, k)
+		}
+		sort.Strings(e.StrconvTypes)
+	}
+
+	// Find the configuration file of the project.
+	e.ConfigFile = e.catch("ConfigFile", func() (string, error) {
+		dir, err := modpath.ToPath(project)
+		if err != nil {
+			return "", err
+		}
+		return existing(filepath.Join(dir, run.ConfigFile), nil)
+	})
+	return e
+}
+
+// catch calls the function and returns its result.
+// If the function returns an error, it is saved using
+// the requested key.
+func (e *environment) catch(key string, fn func() (string, error)) string {
+	v, err := fn()
+	if err != nil {
+		e.Errors[key] = err.Error()
+	}
+	return v
+}
+
+// print writes the information in a human readable form.
+func (e *environment) print(w io.Writer) {
+	show := func(title, key, v string) {
+		if err, ok := e.Errors[key]; ok {
+			v = fmt.Sprintf("%s (error: %s)", v, err)
+		}
+		if v == "" {
+			v = "-"
+		}
+		fmt.Fprintf(w, "%-18s %s\n", title+":", v)
+	}
+	show("GOPATH", "", e.GOPATH)
+	show("GOROOT", "", e.GOROOT)
+	show("Workdir", "", e.Workdir)
+	show("Import path", "ImportPath", e.ImportPath)
+	show("Module root", "ModuleRoot", e.ModuleRoot)
+	show("Module path", "", e.ModulePath)
+	show("Module cache", "", e.ModuleCache)
+	show("Handlers template", "Template", e.Template)
+	show("Skeleton", "Skeleton", e.Skeleton)
+	show("Strconv", "Strconv", e.Strconv)
+	show("Strconv types", "", strings.Join(e.StrconvTypes, " "))
+	show("Config file", "ConfigFile", e.ConfigFile)
+
+	ks := []string{}
+	for k := range e.Vars {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	for _, k := range ks {
+		show("Variable "+k, "", fmt.Sprintf("%q", e.Vars[k]))
+	}
+}
+
+// existing gets a path and an error. If the error is nil,
+// it makes sure the path exists.
+func existing(p string, err error) (string, error) {
+	if err != nil {
+		return p, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func init() {
+	jsonOutput = Handler.Flags.Bool("json", false, "print the information in JSON format")
+}
@@ -0,0 +1,58 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goaltools/goal/utils/tool"
+)
+
+func TestMain(t *testing.T) {
+	var buf bytes.Buffer
+	output = &buf
+	defer func() {
+		output = os.Stdout
+	}()
+
+	if err := main(hs, 0, tool.Data{"github.com/goaltools/goal/tools/run/testdata/configs"}); err != nil {
+		t.Error(err)
+	}
+	for _, exp := range []string{
+		"GOPATH:", "Handlers template:", embedded,
+		"Config file:", filepath.Join("configs", "goal.yml"), `Variable :EXT:`,
+	} {
+		if !strings.Contains(buf.String(), exp) {
+			t.Errorf(`Output is expected to contain "%s", got:\n%s`, exp, buf.String())
+		}
+	}
+}
+
+func TestMain_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	output = &buf
+	*jsonOutput = true
+	defer func() {
+		output = os.Stdout
+		*jsonOutput = false
+	}()
+
+	if err := main(hs, 0, tool.Data{"./testdata/project"}); err != nil {
+		t.Error(err)
+	}
+	e := environment{}
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Errorf(`Output is expected to be a valid JSON. Error: %v.`, err)
+	}
+	if e.Skeleton == "" || e.Strconv == "" || len(e.StrconvTypes) == 0 {
+		t.Errorf(`Skeleton and strconv are expected to be resolved, got %#v.`, e)
+	}
+	if _, ok := e.Errors["ConfigFile"]; !ok {
+		t.Errorf(`There is no configuration file in the project, error expected. Got %#v.`, e.Errors)
+	}
+}
+
+var hs = []tool.Handler{Handler}
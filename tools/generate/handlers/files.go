@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goaltools/goal/internal/diff"
+	"github.com/goaltools/goal/internal/generation"
+	"github.com/goaltools/goal/internal/log"
+)
+
+// Headers of the generated files. The first one is added by the
+// handlers template, the second one is a suffix of the package comment
+// of the files generated by the older versions of goal.
+// They are used to find out what files are owned by the generator.
+const (
+	generatedHeader = "// Code generated by goal toolkit. DO NOT EDIT."
+	legacyHeader    = "is generated automatically by goal toolkit."
+)
+
+// diffOutput is a writer the differences between
+// the generated and existing files are printed to.
+var diffOutput io.Writer = os.Stdout
+
+// files represents generated files, their paths are mapped
+// to their contents.
+type files map[string][]byte
+
+// save writes the files that have changed to the output directory
+// and removes the files that were generated before but are not
+// generated anymore. Other files of the directory are kept as is.
+// The changed files are written to temporary ones first, so existing
+// files are not touched unless all of them have been prepared.
+// The replaced and removed files are kept as backups until all of
+// the changes have been made, and are restored if any of them fails.
+// Temporary files left by a save that has been interrupted
+// are cleaned up first, see cleanup.
+func (fs files) save(out string) (err error) {
+	if err := cleanup(out); err != nil {
+		return err
+	}
+	stale, err := fs.stale(out)
+	if err != nil {
+		return err
+	}
+
+	// Prepare the files that differ from their existing versions.
+	tmps := map[string]string{}
+	defer func() {
+		for _, tmp := range tmps { // Clean up in case of error.
+			os.Remove(tmp)
+		}
+	}()
+	ps := []string{}
+	for _, p := range fs.paths() {
+		if old, err := ioutil.ReadFile(p); err == nil && bytes.Equal(old, fs[p]) {
+			log.Trace.Printf(`File "%s" is up to date.`, p)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		tmp, err := generation.Stage(p, fs[p])
+		if err != nil {
+			return err
+		}
+		tmps[p] = tmp
+		ps = append(ps, p)
+	}
+
+	// Replace the existing files and remove the stale ones.
+	// Every change is recorded, so it can be undone in case of error.
+	cs := []change{}
+	defer func() {
+		if err != nil {
+			rollback(cs)
+		}
+	}()
+	for _, p := range ps {
+		log.Info.Printf(`Saving generated file to "%s".`, p)
+		c, err := backup(p)
+		if err != nil {
+			return err
+		}
+		cs = append(cs, c)
+		if err := rename(tmps[p], p); err != nil {
+			return err
+		}
+		delete(tmps, p)
+	}
+	for _, p := range stale {
+		log.Info.Printf(`Removing "%s" that is not generated anymore.`, p)
+		c, err := backup(p)
+		if err != nil {
+			return err
+		}
+		cs = append(cs, c)
+	}
+
+	// All of the changes have been made, so the backups are not needed anymore.
+	for _, c := range cs {
+		if c.backup != "" {
+			os.Remove(c.backup)
+		}
+	}
+
+	// Remove the directories that have become empty.
+	for _, p := range stale {
+		for dir := filepath.Dir(p); dir != filepath.Clean(out); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil { // The directory is not empty.
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// leftovers matches names of the temporary files and backups save creates
+// next to the files of the output directory, e.g. ".app.go.123" and ".app.go.bak.123".
+// The first group is the name of the file, the second one is not empty for backups.
+var leftovers = regexp.MustCompile(`^\.(.+\.go)\.(bak\.)?[0-9]+$`)
+
+// cleanup resolves the temporary files and backups that are left in
+// the output directory if save has been interrupted, e.g. the process
+// has been killed. Backups of the files that do not exist are restored,
+// so no file is lost. Other leftovers are removed. The files that have been
+// replaced before the interruption are updated by the following save.
+func cleanup(out string) error {
+	return filepath.Walk(out, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if p == out && os.IsNotExist(err) { // Nothing has been generated yet.
+				return nil
+			}
+			return err
+		}
+		m := leftovers.FindStringSubmatch(fi.Name())
+		if fi.IsDir() || m == nil {
+			return nil
+		}
+		orig := filepath.Join(filepath.Dir(p), m[1])
+		if _, err := os.Lstat(orig); m[2] != "" && os.IsNotExist(err) {
+			log.Warn.Printf(`Restoring "%s" from the backup left by interrupted generation.`, orig)
+			return rename(p, orig)
+		}
+		log.Warn.Printf(`Removing "%s" left by interrupted generation.`, p)
+		return os.Remove(p)
+	})
+}
+
+// rename is used by save for moving files,
+// it is replaced by tests to simulate failures.
+var rename = os.Rename
+
+// change represents a file of the output directory that has been
+// replaced or removed by save. Its previous version is kept at backup
+// path. The backup is empty if the file did not exist before.
+type change struct {
+	path, backup string
+}
+
+// backup moves the file to a temporary one in the same directory
+// and returns the change. Files that do not exist are not moved.
+func backup(p string) (change, error) {
+	c := change{path: p}
+	if _, err := os.Lstat(p); os.IsNotExist(err) {
+		return c, nil
+	}
+	f, err := ioutil.TempFile(filepath.Dir(p), "."+filepath.Base(p)+".bak.")
+	if err != nil {
+		return c, err
+	}
+	f.Close()
+	if err := rename(p, f.Name()); err != nil {
+		os.Remove(f.Name())
+		return c, err
+	}
+	c.backup = f.Name()
+	return c, nil
+}
+
+// rollback undoes the changes in reverse order, i.e. restores the
+// backups and removes the files that did not exist before.
+func rollback(cs []change) {
+	for i := len(cs) - 1; i >= 0; i-- {
+		if cs[i].backup == "" {
+			os.Remove(cs[i].path)
+			continue
+		}
+		if err := rename(cs[i].backup, cs[i].path); err != nil {
+			log.Error.Printf(`Cannot restore "%s", its previous version is at "%s": %v.`, cs[i].path, cs[i].backup, err)
+		}
+	}
+}
+
+// stale returns sorted paths of the files in the output directory that
+// are owned by the generator (see owned) but are not generated anymore.
+func (fs files) stale(out string) (ps []string, err error) {
+	err = filepath.Walk(out, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if p == out && os.IsNotExist(err) { // Nothing has been generated yet.
+				return nil
+			}
+			return err
+		}
+		if _, ok := fs[p]; ok || fi.IsDir() || !owned(p) {
+			return nil
+		}
+		ps = append(ps, p)
+		return nil
+	})
+	return
+}
+
+// owned checks whether the file has been created by the generator,
+// i.e. it is a go file with the generated code header.
+// Header of the older versions of goal is recognized, too.
+func owned(p string) bool {
+	if filepath.Ext(p) != ".go" {
+		return false
+	}
+	d, err := ioutil.ReadFile(p)
+	if err != nil {
+		return false
+	}
+
+	// The header must be above the package clause.
+	for _, l := range strings.Split(string(d), "\n") {
+		l = strings.TrimSpace(l)
+		if l == generatedHeader || strings.HasPrefix(l, "// Package ") && strings.HasSuffix(l, legacyHeader) {
+			return true
+		}
+		if strings.HasPrefix(l, "package ") {
+			break
+		}
+	}
+	return false
+}
+
+// check compares the files with the ones in the output directory.
+// If some of them differ, their diffs are printed and an error is returned.
+func (fs files) check(out string) error {
+	n, err := fs.diff(out, diffOutput)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf(`%d file(s) in "%s" are out of date, run "generate handlers" to update them`, n, out)
+	}
+	log.Info.Printf(`Generated files in "%s" are up to date.`, out)
+	return nil
+}
+
+// diff writes a unified diff of every file that differs from its version
+// in the output directory to w. Files of the directory that are owned by
+// the generator but are not generated anymore are shown as removed. The number of the files that
+// differ is returned.
+func (fs files) diff(out string, w io.Writer) (n int, err error) {
+	stale, err := fs.stale(out)
+	if err != nil {
+		return 0, err
+	}
+	ps := append(fs.paths(), stale...)
+	sort.Strings(ps)
+
+	for _, p := range ps {
+		oldName, newName := "a/"+filepath.ToSlash(p), "b/"+filepath.ToSlash(p)
+		old, err := ioutil.ReadFile(p)
+		if os.IsNotExist(err) {
+			oldName = "/dev/null"
+		} else if err != nil {
+			return n, err
+		}
+		data, ok := fs[p]
+		if !ok {
+			newName = "/dev/null"
+		}
+		if d := diff.Unified(oldName, newName, old, data); d != "" {
+			fmt.Fprint(w, d)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// paths returns sorted paths of the files.
+func (fs files) paths() []string {
+	ps := []string{}
+	for p := range fs {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	return ps
+}
@@ -3,52 +3,61 @@
 package handlers
 
 import (
-	"os"
+	"fmt"
 	"path/filepath"
 	"strings"
 
 	"github.com/goaltools/goal/internal/action"
 	"github.com/goaltools/goal/internal/generation"
 	"github.com/goaltools/goal/internal/log"
+	"github.com/goaltools/goal/internal/modpath"
 	"github.com/goaltools/goal/internal/routes"
-
-	"github.com/conveyer/importpath"
+	"github.com/goaltools/goal/internal/strconv"
 )
 
 // start is an entry point of the generate handlers command.
-func start() {
-	// Clean the out directory.
-	log.Trace.Printf(`Removing "%s" directory if already exists...`, *output)
-	err := os.RemoveAll(*output)
-	if err != nil {
-		log.Error.Panic(err)
+func start() error {
+	// Make sure types supported by strconv are known.
+	if action.StrconvErr != nil {
+		return action.StrconvErr
 	}
 
 	// Start processing of controllers.
 	ps := packages{}
-	absImport, err := importpath.ToImport(*input)
+	absImport, err := modpath.ToImport(*input)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
-	absImportOut, err := importpath.ToImport(*output)
+	absImportOut, err := modpath.ToImport(*output)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
 	log.Trace.Printf(`Processing "%s" package...`, absImport)
-	ps.processPackage(absImport, routes.NewPrefixes())
+	if err := ps.processPackage(absImport, routes.NewPrefixes()); err != nil {
+		return err
+	}
 
 	// Start generation of handler packages.
-	tpl, err := importpath.ToPath("github.com/goaltools/goal/tools/generate/handlers/handlers.go.template")
+	t, err := generation.ParseType("", "handlers.go.template", DefaultTemplate)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
+	}
+	if *templatePath != "" {
+		log.Trace.Printf(`Using "%s" template...`, *templatePath)
+		if t, err = generation.NewType("", *templatePath); err != nil {
+			return err
+		}
 	}
-	t := generation.NewType("", tpl)
 	t.Extension = ".go" // Save generated files as a .go source.
 
 	// Iterate through all available packages and generate handlers for them.
+	// The files are kept in memory until it is known what to do with them.
+	fs := files{}
 	// TODO: refactor this fragment. Consider use of fmt.Sprintf instead of html/template.
 	log.Trace.Printf(`Starting generation of "%s" package...`, *pkg)
-	for imp := range ps {
+	// Packages and controllers are sorted so the result does not
+	// depend on the order of iteration over maps.
+	for _, imp := range ps.imports() {
 		// Check whether current package is the main one
 		// and should be stored at the root directory or it is a subpackage.
 		//
@@ -59,33 +68,62 @@ func start() {
 		if imp != absImport {
 			out = filepath.Join(out, filepath.FromSlash(imp))
 		}
-		t.CreateDir(out)
+		t.Path = out
 
 		// Iterate over all available controllers, generate handlers package on
 		// every of them.
-		n := 0
-		for name := range ps[imp].data {
-			// Find parent controllers of this controller.
-			cs := []parent{}
-			for i, p := range ps[imp].data[name].Parents {
-				// Make sure it is a controller rather than just some embedded struct.
-				check := p.Import
-				if check == "" { // Embedded parent is a local structure.
-					check = absImport
-				}
-				if _, ok := ps[check]; !ok { // Such package is not in the list of scanned ones.
-					continue
-				}
-				if _, ok := ps[check].data[p.Name]; !ok { // There is no such controller.
-					continue
-				}
+		n, roots, shared := 0, ps.roots(imp), ps.shared(imp)
+		for _, name := range ps[imp].names() {
+			// Generic controllers are generated for every of their instances.
+			c := ps[imp].data[name]
+			if len(c.TypeParams) > 0 {
+				continue
+			}
+			typ := name
+			if c.Instance != "" {
+				typ = c.Instance
+			}
+
+			// Make sure handlers of the actions can be generated.
+			hs := helpers
+			if *pool {
+				hs = append(append([]string{}, helpers...), poolHelpers...)
+			}
+			if err := c.collision(name, hs); err != nil {
+				return err
+			}
+
+			// Make sure actions promoted from the parent controllers are not ambiguous.
+			// Routes of the parents' actions that are not promoted are omitted.
+			shadowed, err := ps.shadowed(imp, name)
+			if err != nil {
+				return err
+			}
+
+			// Make sure the controllers the actions skip are known.
+			if err := ps.checkSkips(imp, name); err != nil {
+				return err
+			}
+
+			// Types of other packages that are used by parameters
+			// of the actions require additional imports.
+			b := strconv.Binder{
+				FnMap:   action.StrconvContext,
+				Pkg:     ps[imp].pkg,
+				PkgName: "contr",
+				Load:    strconv.LoadPackage,
+			}
+			if b.Imports, err = ps[imp].data[name].imports(b); err != nil {
+				return err
+			}
 
-				// It is a valid parent controller, add it to the list.
-				cs = append(cs, parent{
-					ID:     i,
-					Import: p.Import,
-					Name:   p.Name,
-				})
+			// Structures the parents are promoted through may be declared
+			// in other packages, too.
+			ptrs := ps.pointers(imp, name)
+			for _, p := range ptrs {
+				if _, ok := b.Imports[p.Import]; p.Import != "" && !ok {
+					b.Imports[p.Import] = fmt.Sprintf("i%d", len(b.Imports))
+				}
 			}
 
 			// Initialize parameters and generate a package.
@@ -99,19 +137,40 @@ func start() {
 				"import":       imp,
 				"input":        input,
 				"name":         name,
+				"type":         typ,
 				"outputImport": absImportOut,
 				"output":       output,
 				"package":      pkg,
-				"parents":      cs,
+				"parents":      ps.parents(imp, name),
+				"pointers":     ptrs,
+				"shadowed":     shadowed,
+				"roots":        roots,
+				"shared":       shared,
 				"initFunc":     ps[imp].init,
 				"num":          n,
+				"pool":         *pool,
 
 				"actionImport":    action.InterfaceImport,
 				"actionInterface": action.Interface,
-				"strconv":         action.StrconvContext,
+				"imports":         b.Imports,
+				"strconv":         b,
+			}
+			if fs[t.File()], err = t.Render(); err != nil {
+				return err
 			}
-			t.Generate()
 			n++
 		}
 	}
+
+	// Either compare the generated files with the existing ones
+	// or update the output directory.
+	switch {
+	case *check:
+		return fs.check(*output)
+	case *dryRun:
+		n, err := fs.diff(*output, diffOutput)
+		log.Info.Printf(`%d file(s) in "%s" would be changed.`, n, *output)
+		return err
+	}
+	return fs.save(*output)
 }
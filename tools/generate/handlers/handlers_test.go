@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/goaltools/goal/utils/tool"
 )
 
 func TestStart(t *testing.T) {
-	main(handlers, 0, tool.Data{})
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
 
 	cmd := exec.Command("go", "install", "github.com/goaltools/goal/tools/generate/handlers/testdata/assets/handlers")
 	cmd.Stderr = os.Stderr // Show the output of the program we run.
@@ -21,6 +29,241 @@ func TestStart(t *testing.T) {
 	os.RemoveAll(*output)
 }
 
+func TestStart_Check(t *testing.T) {
+	var buf bytes.Buffer
+	diffOutput = &buf
+	defer func() {
+		diffOutput = os.Stdout
+		*check, *dryRun = false, false
+		os.RemoveAll(*output)
+	}()
+
+	// Nothing has been generated yet, so nothing must be written
+	// in dry-run mode and the check must fail.
+	*dryRun = true
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(*output); !os.IsNotExist(err) {
+		t.Errorf(`Output directory is not expected to be created in dry-run mode.`)
+	}
+	if !strings.Contains(buf.String(), "--- /dev/null\n") {
+		t.Errorf("New files are expected to be shown, got:\n%s", buf.String())
+	}
+	*dryRun, *check = false, true
+	if err := main(handlers, 0, tool.Data{}); err == nil {
+		t.Errorf(`Files are not generated, check is expected to fail.`)
+	}
+
+	// Up to date files must pass the check.
+	*check = false
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	*check = true
+	buf.Reset()
+	if err := main(handlers, 0, tool.Data{}); err != nil || buf.Len() != 0 {
+		t.Errorf("Generated files are up to date, got error `%v` and diff:\n%s", err, buf.String())
+	}
+
+	// Modified and unknown files must be detected and kept.
+	file := filepath.Join(*output, "app.go")
+	ioutil.WriteFile(file, []byte("package handlers\n"), 0644)
+	ioutil.WriteFile(filepath.Join(*output, "stale.go"), []byte(generatedHeader+"\n\npackage handlers\n"), 0644)
+	if err := main(handlers, 0, tool.Data{}); err == nil {
+		t.Errorf(`Files are modified, check is expected to fail.`)
+	}
+	for _, exp := range []string{"--- a/" + filepath.ToSlash(file), "+++ /dev/null", "-package handlers"} {
+		if !strings.Contains(buf.String(), exp) {
+			t.Errorf("Diff is expected to contain `%s`, got:\n%s", exp, buf.String())
+		}
+	}
+	if d, _ := ioutil.ReadFile(file); string(d) != "package handlers\n" {
+		t.Errorf(`Files are not expected to be changed in check mode.`)
+	}
+}
+
+func TestStart_Incremental(t *testing.T) {
+	defer os.RemoveAll(*output)
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Prepare a file of the developer, a file that is not generated anymore,
+	// and make an unchanged file look old.
+	foreign := filepath.Join(*output, "README.md")
+	ioutil.WriteFile(foreign, []byte("Do not remove me.\n"), 0644)
+	stale := filepath.Join(*output, "github.com", "removed", "removed.go")
+	os.MkdirAll(filepath.Dir(stale), 0755)
+	ioutil.WriteFile(stale, []byte("// Package removed "+legacyHeader+"\npackage removed\n"), 0644)
+	file := filepath.Join(*output, "app.go")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	os.Chtimes(file, old, old)
+
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(foreign); err != nil {
+		t.Errorf(`Files that are not owned by the generator must be kept. Error: %v.`, err)
+	}
+	if _, err := os.Stat(filepath.Join(*output, "github.com", "removed")); !os.IsNotExist(err) {
+		t.Errorf(`Stale generated file and its empty directories are expected to be removed.`)
+	}
+	if fi, err := os.Stat(file); err != nil || !fi.ModTime().Equal(old) {
+		t.Errorf(`Unchanged files are not expected to be rewritten. Error: %v.`, err)
+	}
+	ds, _ := filepath.Glob(filepath.Join(*output, ".*"))
+	if len(ds) != 0 {
+		t.Errorf(`Temporary files are expected to be removed, got %v.`, ds)
+	}
+}
+
+func TestStart_Rollback(t *testing.T) {
+	defer func() {
+		rename = os.Rename
+		os.RemoveAll(*output)
+	}()
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Make two files out of date and fail when the second one is saved.
+	app, ctr := filepath.Join(*output, "app.go"), filepath.Join(*output, "controller.go")
+	ioutil.WriteFile(app, []byte("package handlers\n"), 0644)
+	ioutil.WriteFile(ctr, []byte("package handlers\n"), 0644)
+	rename = func(from, to string) error {
+		if to == ctr && !strings.Contains(from, ".bak.") {
+			return os.ErrPermission
+		}
+		return os.Rename(from, to)
+	}
+	if err := main(handlers, 0, tool.Data{}); err == nil {
+		t.Errorf(`Saving is expected to fail.`)
+	}
+	for _, f := range []string{app, ctr} {
+		if d, _ := ioutil.ReadFile(f); string(d) != "package handlers\n" {
+			t.Errorf("File `%s` is expected to be restored, got:\n%s", f, d)
+		}
+	}
+	ds, _ := filepath.Glob(filepath.Join(*output, ".*"))
+	if len(ds) != 0 {
+		t.Errorf(`Temporary files and backups are expected to be removed, got %v.`, ds)
+	}
+}
+
+func TestStart_Interrupted(t *testing.T) {
+	defer os.RemoveAll(*output)
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a save that has been interrupted after app.go was moved
+	// to its backup and a new version of controller.go was staged.
+	app, ctr := filepath.Join(*output, "app.go"), filepath.Join(*output, "controller.go")
+	if err := os.Rename(app, filepath.Join(*output, ".app.go.bak.123")); err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(filepath.Join(*output, ".controller.go.456"), []byte("package handlers\n"), 0644)
+	ioutil.WriteFile(filepath.Join(*output, ".controller.go.bak.789"), []byte("package handlers\n"), 0644)
+
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{app, ctr} {
+		if d, _ := ioutil.ReadFile(f); !strings.Contains(string(d), generatedHeader) {
+			t.Errorf("File `%s` is expected to be generated, got:\n%s", f, d)
+		}
+	}
+	ds, _ := filepath.Glob(filepath.Join(*output, ".*"))
+	if len(ds) != 0 {
+		t.Errorf(`Temporary files and backups left by interrupted save are expected to be removed, got %v.`, ds)
+	}
+}
+
+func TestStart_Template(t *testing.T) {
+	tpl := filepath.Join(os.TempDir(), "custom.go.template")
+	ioutil.WriteFile(tpl, []byte(DefaultTemplate+"\n// Generated using a custom template.\n"), 0644)
+	*templatePath = tpl
+	defer func() {
+		*templatePath = ""
+		os.Remove(tpl)
+		os.RemoveAll(*output)
+	}()
+
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := ioutil.ReadFile(filepath.Join(*output, "app.go")); !strings.Contains(string(d), "custom template") {
+		t.Errorf("Custom template is expected to be used, got:\n%s", d)
+	}
+}
+
+func TestStart_Pool(t *testing.T) {
+	*pool = true
+	defer func() {
+		*pool = false
+		os.RemoveAll(*output)
+	}()
+
+	if err := main(handlers, 0, tool.Data{}); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "install", "github.com/goaltools/goal/tools/generate/handlers/testdata/assets/handlers")
+	cmd.Stderr = os.Stderr // Show the output of the program we run.
+	if err := cmd.Run(); err != nil {
+		t.Errorf(`There are problems with generated pooled handlers, error: "%s".`, err)
+	}
+}
+
+func TestStart_Bench(t *testing.T) {
+	testFixture(t, "bench", map[string]bool{"plain": false, "pooled": true})
+}
+
+func TestStart_Inherit(t *testing.T) {
+	testFixture(t, "inherit", map[string]bool{"handlers": false})
+}
+
+// testFixture makes sure the packages generated from the controllers
+// of the testdata directory are up to date and runs tests of the directory.
+// Names of the generated packages are mapped to the values of --pool flag.
+func testFixture(t *testing.T, dir string, pkgs map[string]bool) {
+	defer func() {
+		Handler.Flags.Set("input", "./testdata/controllers")
+		Handler.Flags.Set("output", "./testdata/assets/handlers")
+		Handler.Flags.Set("package", "handlers")
+		*check, *pool = false, false
+	}()
+
+	Handler.Flags.Set("input", "./testdata/"+dir+"/controllers")
+	*check = true
+	for pkg, p := range pkgs {
+		Handler.Flags.Set("output", "./testdata/"+dir+"/"+pkg)
+		Handler.Flags.Set("package", pkg)
+		*pool = p
+		if err := main(handlers, 0, tool.Data{}); err != nil {
+			t.Errorf(`Handlers in "%s/%s" are out of date: %v.`, dir, pkg, err)
+		}
+	}
+
+	cmd := exec.Command("go", "test", "github.com/goaltools/goal/tools/generate/handlers/testdata/"+dir)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr // Show the output of the tests we run.
+	if err := cmd.Run(); err != nil {
+		t.Errorf(`Tests of the handlers in "%s" have failed, error: "%s".`, dir, err)
+	}
+}
+
+func TestOwned(t *testing.T) {
+	for f, exp := range map[string]bool{
+		"./testdata/controllers/app.go": false,
+		"./handlers.go.template":        false,
+		"./files.go":                    false,
+	} {
+		if res := owned(f); res != exp {
+			t.Errorf(`"%s": expected %v, got %v.`, f, exp, res)
+		}
+	}
+}
+
 var handlers []tool.Handler
 
 func init() {
@@ -3,30 +3,65 @@
 package handlers
 
 import (
+	_ "embed" // Used for the default template.
+
 	"github.com/goaltools/goal/utils/tool"
 )
 
+// DefaultTemplate is a template that is used for generation
+// of handlers unless another one is requested using --template flag.
+// It is embedded into the binary, so source code of goal is not needed.
+//
+//go:embed handlers.go.template
+var DefaultTemplate string
+
 // Handler is an instance of "generate handlers" subcommand (tool).
 var Handler = tool.Handler{
 	Run: main,
 
-	Name:  "generate handlers",
-	Usage: "[flags]",
-	Info:  "generate handler functions from controllers",
+	Name:    "generate handlers",
+	Aliases: []string{"g h"},
+	Usage:   "[flags]",
+	Info:    "generate handler functions from controllers",
 	Desc: `Tool "generate handlers" scans your controllers and generates
 a standard handler function for every of your action.
 So, you can use the generated package with any router you want.
+Only files that have changed are written. Files that are not generated
+anymore are removed, other files of the output directory are kept.
+
+The default template of the generated files is a part of the binary.
+Use --template to provide your own one, it receives the same data,
+so it can be a copy of the default template with some changes,
+e.g. logging or metrics added to every handler.
+
+Use --pool to make the generated handlers reuse controllers rather
+than allocate them on every request. Controllers are zeroed before
+they are reused. Controllers with magic Reset methods are not, only
+their fields with "bind" tags are reset and the methods are called,
+so they can keep e.g. buffers but must clear the rest of the fields.
+
+Use --check to make sure the generated package is up to date
+without changing it (e.g. in CI), and --dry-run to see what would be
+written. Both print unified diffs of the files that differ.
 `,
 }
 
-var input, output, pkg *string
+var (
+	input, output, pkg  *string
+	templatePath        *string
+	check, dryRun, pool *bool
+)
 
-func main(hs []tool.Handler, i int, args tool.Data) {
-	start()
+func main(hs []tool.Handler, i int, args tool.Data) error {
+	return start()
 }
 
 func init() {
 	input = Handler.Flags.String("input", "./controllers", "a path to directory with controllers to scan")
 	output = Handler.Flags.String("output", "./assets/handlers", "a directory where generated package must be saved")
 	pkg = Handler.Flags.String("package", "handlers", "name of the package to generate")
+	templatePath = Handler.Flags.String("template", "", "a path to the template of generated files (the default one is embedded)")
+	check = Handler.Flags.Bool("check", false, "fail if the generated package is out of date, do not write anything")
+	dryRun = Handler.Flags.Bool("dry-run", false, "show what would be written, do not write anything")
+	pool = Handler.Flags.Bool("pool", false, "reuse controllers allocated by the handlers using sync.Pool")
 }
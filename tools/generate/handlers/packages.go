@@ -3,15 +3,17 @@ package handlers
 import (
 	"fmt"
 	"go/ast"
+	"go/types"
 	r "reflect"
+	"sort"
 	"strings"
 
 	a "github.com/goaltools/goal/internal/action"
 	"github.com/goaltools/goal/internal/log"
+	"github.com/goaltools/goal/internal/modpath"
 	"github.com/goaltools/goal/internal/reflect"
 	"github.com/goaltools/goal/internal/routes"
-
-	"github.com/conveyer/importpath"
+	"github.com/goaltools/goal/internal/strconv"
 )
 
 // packages represents packages of controllers. The format is the following:
@@ -27,6 +29,7 @@ type packages map[string]controllers
 type controllers struct {
 	data map[string]controller
 	init *reflect.Func
+	pkg  *reflect.Package // Parsed package, it is used for binding of local structures.
 }
 
 // parents represents a set of parent controllers.
@@ -38,6 +41,16 @@ type parent struct {
 	ID     int    // Unique number that is used for generation of import names.
 	Import string // Import path of the structure, e.g. "github.com/goaltools/goal/template" or "".
 	Name   string // Name of the structure, e.g. "Template".
+	Field  string // Selector of the embedded field, e.g. "Template" or "Base.Template".
+	Star   bool   // Whether the structure is embedded as a pointer.
+	Tag    string // Tag of the embedded field, it may contain route prefixes.
+
+	Args []*reflect.Type // Type arguments of a generic structure, e.g. "User" in case of "Resource[User]".
+
+	// Pointers are structures embedded as pointers the parent is promoted through,
+	// e.g. "Base" in case of "Base.Template" where "Base" is "*Base".
+	// They must be allocated before the parent is initialized.
+	Pointers []parent
 }
 
 // field represents a field of a structure that must be automatically binded.
@@ -52,13 +65,23 @@ type controller struct {
 	Actions reflect.Funcs // Actions are methods that implement action.Result interface.
 	After   *reflect.Func // Magic method that is executed after actions if they return nil.
 	Before  *reflect.Func // Magic method that is executed before every action.
+	OnError *reflect.Func // Magic method that is executed if an action returns an error.
+	Recover *reflect.Func // Magic method that is executed if an action or a magic method panics.
+	Finally *reflect.Func // Magic method that is executed at the very end of every request.
+	Reset   *reflect.Func // Magic method that is executed before the controller is put back to the pool.
 
 	Comments reflect.Comments // A group of comments right above the controller declaration.
+	Uses     []a.Middleware   // Middleware that wrap handlers of all actions of the controller.
 	File     string           // Name of the file where this controller is located.
 	Parents  parents          // A list of embedded structs that should be parsed.
 
 	Fields []field          // A list of fields that require binding.
 	Routes [][]routes.Route // Routes concatenated with prefixes. len(Routes) = len(Actions)
+
+	TypeParams []string // Type parameters of a generic controller, e.g. "T" in case of "Resource[T any]".
+	Instance   string   // Type of an instance of a generic controller, e.g. "Resource[contr.User]".
+
+	pkg *reflect.Package // Package the controller is declared in.
 }
 
 // Package returns a unique package name that may be used in templates
@@ -79,6 +102,24 @@ func (p parent) Package(suffixes ...string) string {
 	return s
 }
 
+// Value returns code that gets a pointer to the embedded parent
+// of the controller "c", e.g. "c.Template" or "&c.Base.Template".
+func (p parent) Value() string {
+	if p.Star {
+		return "c." + p.Field
+	}
+	return "&c." + p.Field
+}
+
+// Type returns code of the structure's type, e.g. "contr.Base" or "i0.Base".
+// Names of the imports of the structures declared in other packages are expected.
+func (p parent) Type(imps map[string]string) string {
+	if p.Import == "" {
+		return "contr." + p.Name
+	}
+	return imps[p.Import] + "." + p.Name
+}
+
 // IgnoredArgs gets an action Func as input parameter
 // and returns blank identifiers for parameters
 // other than the first one.
@@ -93,23 +134,286 @@ func (c controller) IgnoredArgs(f *reflect.Func) (s string) {
 	return
 }
 
+// Results is similar to IgnoredArgs but if the last result
+// of the action is of error type, it is named "err" rather than
+// ignored. E.g. if the action returns http.Handler, bool, error,
+// this method will return ", _, err".
+func (c controller) Results(f *reflect.Func) string {
+	s := c.IgnoredArgs(f)
+	if a.ReturnsError(f) {
+		s = s[:len(s)-1] + "err"
+	}
+	return s
+}
+
+// ReturnsError checks whether the last result of the action
+// is of error type and thus must be handled.
+func (c controller) ReturnsError(f *reflect.Func) bool {
+	return a.ReturnsError(f)
+}
+
+// Body gets an action Func and returns its parameter
+// that must be decoded from the request body.
+// If there is no such parameter, nil is returned.
+func (c controller) Body(f *reflect.Func) *reflect.Arg {
+	b, _ := a.Body(f)
+	return b
+}
+
+// IsBody checks whether the parameter of the action
+// must be decoded from the request body.
+func (c controller) IsBody(f *reflect.Func, p reflect.Arg) bool {
+	b := c.Body(f)
+	return b != nil && b.Name == p.Name
+}
+
+// Source returns code that gets url.Values the parameter of the action
+// or magic method must be bound from, see action.Source.
+func (c controller) Source(f *reflect.Func, p reflect.Arg) (string, error) {
+	return a.Source(f, p.Name)
+}
+
+// IsContext checks whether the i-th parameter of the action
+// or magic method is a context, see action.Context.
+func (c controller) IsContext(f *reflect.Func, i int) bool {
+	return i == 0 && c.pkg != nil && a.Context(c.pkg, f)
+}
+
+// bound checks whether the i-th parameter of the action
+// must be bound from the form, i.e. it is neither a request body
+// nor a context.
+func (c controller) bound(f *reflect.Func, i int) bool {
+	return !c.IsBody(f, f.Params[i]) && !c.IsContext(f, i)
+}
+
+// Skip gets an action Func and returns magic methods it omits,
+// see action.Skips. Local controllers are identified using
+// the requested import path of the controller's package.
+func (c controller) Skip(imp string, f *reflect.Func) (a.Skip, error) {
+	return a.Skips(c.pkg, imp, f)
+}
+
+// HasBody checks whether at least one of the actions
+// of the controller expects a request body.
+func (c controller) HasBody() bool {
+	for i := range c.Actions {
+		if c.Body(&c.Actions[i]) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks gets an action Func and returns code that validates
+// its parameters, see strconv.Binder.Checks for details.
+// The body parameter is not validated.
+func (c controller) Checks(b strconv.Binder, f *reflect.Func) (res []string, err error) {
+	b = b.For(f)
+	for i, p := range f.Params {
+		if !c.bound(f, i) {
+			continue
+		}
+		vs, err := c.Source(f, p)
+		if err != nil {
+			return nil, err
+		}
+		cs, err := b.Checks("validation", vs, p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cs...)
+	}
+	return
+}
+
+// imports returns import paths of other packages that are used
+// by parameters of the actions and magic methods of the controller,
+// and unique names they must be imported as by the generated code.
+func (c controller) imports(b strconv.Binder) (map[string]string, error) {
+	fs := append(reflect.Funcs{}, c.Actions...)
+	for _, f := range []*reflect.Func{c.Before, c.After} {
+		if f != nil {
+			fs = append(fs, *f)
+		}
+	}
+	imps := []string{}
+	for i := range fs {
+		for j, p := range fs[i].Params {
+			if c.bound(&fs[i], j) {
+				imps = append(imps, b.In(fs[i].File).Deps(p)...)
+			}
+		}
+	}
+
+	// Middleware of the actions are used only if the actions have routes.
+	ms := append([]a.Middleware{}, c.Uses...)
+	for _, rs := range c.Routes {
+		if len(rs) == 0 {
+			continue
+		}
+		us, err := c.uses(rs[0])
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, us...)
+	}
+	for _, m := range ms {
+		if m.Import != "" {
+			imps = append(imps, m.Import)
+		}
+	}
+	sort.Strings(imps)
+	res := map[string]string{}
+	for _, imp := range imps {
+		if _, ok := res[imp]; !ok {
+			res[imp] = fmt.Sprintf("i%d", len(res))
+		}
+	}
+	return res, nil
+}
+
+// Handler returns code of the handler function of the route.
+// If the route's action uses middleware, the handler is wrapped
+// with them, e.g.:
+//	i0.Gzip(http.HandlerFunc(App.Index)).ServeHTTP
+// Middleware of the controller are not applied here, see Wrap.
+func (c controller) Handler(imps map[string]string, r routes.Route) (string, error) {
+	ms, err := c.uses(r)
+	if err != nil || len(ms) == 0 {
+		return r.HandlerName, err
+	}
+	return wrap(imps, ms, "http.HandlerFunc("+r.HandlerName+")") + ".ServeHTTP", nil
+}
+
+// Wrap returns code that wraps the handler with middleware of the controller.
+func (c controller) Wrap(imps map[string]string, h string) string {
+	return wrap(imps, c.Uses, h)
+}
+
+// uses returns middleware of the action the route is associated with.
+func (c controller) uses(r routes.Route) ([]a.Middleware, error) {
+	name := r.HandlerName[strings.LastIndex(r.HandlerName, ".")+1:]
+	for i := range c.Actions {
+		if c.Actions[i].Name != name {
+			continue
+		}
+		return a.Uses(c.pkg, c.Actions[i].File, c.Actions[i].Comments)
+	}
+	return nil, nil
+}
+
+// wrap returns code that wraps the handler with the middleware
+// using names the packages are imported as. The first middleware
+// is the outermost one.
+func wrap(imps map[string]string, ms []a.Middleware, h string) string {
+	for i := len(ms) - 1; i >= 0; i-- {
+		pkg := "contr"
+		if ms[i].Import != "" {
+			pkg = imps[ms[i].Import]
+		}
+		h = fmt.Sprintf("%s.%s(%s)", pkg, ms[i].Name, h)
+	}
+	return h
+}
+
+// helpers are names of the methods the generated handlers of every controller
+// have in addition to the handlers of its actions.
+var helpers = []string{
+	"New", "SetErrors", a.MethodBefore, a.MethodAfter, a.MethodOnError, a.MethodRecover, a.MethodFinally,
+}
+
+// poolHelpers are names of the methods the generated handlers
+// have in addition to the helpers if controllers are pooled.
+var poolHelpers = []string{"Bind", a.MethodReset, "Release"}
+
+// collision returns an error if one of the actions of the controller
+// has the same name as one of the helper methods of its handlers.
+func (c controller) collision(name string, helpers []string) error {
+	for i := range c.Actions {
+		for _, h := range helpers {
+			if c.Actions[i].Name == h {
+				return fmt.Errorf(
+					`action "%s.%s" collides with "%s" method of the generated handlers, it must be renamed`,
+					name, h, h,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSkips warns about controllers that are omitted by actions
+// of the requested controller but are neither the controller itself
+// nor one of its parents. Such skips have no effect.
+// An error is returned if the skip directives are invalid.
+func (ps packages) checkSkips(imp, name string) error {
+	c := ps[imp].data[name]
+	for i := range c.Actions {
+		sk, err := c.Skip(imp, &c.Actions[i])
+		if err != nil {
+			return err
+		}
+		for _, s := range sk.Parents {
+			if !ps.embeds(imp, name, s, map[string]bool{}) {
+				log.Warn.Printf(
+					`Action "%s.%s" skips "%s" that is not its parent controller, the directive is ignored.`,
+					name, c.Actions[i].Name, s,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// embeds checks whether the controller is the requested one
+// or embeds it, directly or through its parents.
+func (ps packages) embeds(imp, name, ctr string, visited map[string]bool) bool {
+	k := imp + "." + name
+	if k == ctr {
+		return true
+	}
+	if visited[k] {
+		return false
+	}
+	visited[k] = true
+	for _, p := range ps.parents(imp, name) {
+		pi := p.Import
+		if pi == "" { // Embedded parent is a local structure.
+			pi = imp
+		}
+		if ps.embeds(pi, p.Name, ctr, visited) {
+			return true
+		}
+	}
+	return false
+}
+
 // processPackage gets an import path of a package and its
 // route prefixes, processes this data, and
 // extracts controllers + actions.
-func (ps packages) processPackage(importPath string, prefs routes.Prefixes) {
+// An error is returned if the package cannot be found or parsed.
+func (ps packages) processPackage(importPath string, prefs routes.Prefixes) error {
 	log.Trace.Printf(`Parsing "%s"...`, importPath)
-	dir, err := importpath.ToPath(importPath)
+	dir, err := modpath.ToPath(importPath)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
+	}
+	p, err := reflect.ParseDir(dir, false)
+	if err != nil {
+		return err
+	}
+	cs, err := ps.extractControllers(p, prefs)
+	if err != nil {
+		return err
 	}
-	p := reflect.ParseDir(dir, false)
-	cs := ps.extractControllers(p, prefs)
 	if len(cs.data) > 0 {
 		ps[importPath] = controllers{
 			data: cs.data,
 			init: ps.extractInitFunc(p),
+			pkg:  p,
 		}
 	}
+	return nil
 }
 
 // needBindingField gets a package, an index of struct and index of field
@@ -141,6 +445,28 @@ func (ps packages) needBindingField(pkg *reflect.Package, i, j int) *field {
 			return nil
 		}
 		f.Type = st
+	case "errors":
+		// Make sure "validation" package is imported.
+		n, ok := pkg.Imports.Name(pkg.Structs[i].File, "github.com/goaltools/goal/validation")
+		if !ok || t.Type.String() != fmt.Sprintf("%s.Errors", n) {
+			log.Warn.Printf(
+				`Field "%s" in controller "%s" cannot be binded. Errors must be of type "(github.com/goaltools/goal/validation).Errors".`,
+				t.Name, pkg.Structs[i].Name,
+			)
+			return nil
+		}
+		f.Type = st
+	case "context":
+		// Make sure "context" package is imported.
+		n, ok := pkg.Imports.Name(pkg.Structs[i].File, a.ContextImport)
+		if !ok || t.Type.String() != fmt.Sprintf("%s.Context", n) {
+			log.Warn.Printf(
+				`Field "%s" in controller "%s" cannot be binded. Context must be of type "(context).Context".`,
+				t.Name, pkg.Structs[i].Name,
+			)
+			return nil
+		}
+		f.Type = st
 	case "controller":
 		if t.Type.String() != "string" {
 			log.Warn.Printf(
@@ -180,37 +506,279 @@ func (ps packages) needBindingField(pkg *reflect.Package, i, j int) *field {
 // Every anonymously embedded type is checked recursively regarding being a controller.
 // As a result a list of all found fields with the tags and
 // types in a form of []parent are returned.
-func (ps packages) scanFields(pkg *reflect.Package, i int) (fs []field, prs []parent) {
+func (ps packages) scanFields(pkg *reflect.Package, i int) (fs []field, prs []parent, err error) {
 	// Iterating over fields of the structure.
 	for j := range pkg.Structs[i].Fields {
 		// Check whether the field requires binding.
 		if f := ps.needBindingField(pkg, i, j); f != nil {
 			fs = append(fs, *f)
-			continue
 		}
+	}
+	prs, err = ps.embedded(pkg, "", &pkg.Structs[i], "")
+	return fs, prs, err
+}
+
+// embedded returns structures that are anonymously embedded into the requested one.
+// Import path of the package the structure is declared in is expected,
+// empty string stands for the local package. Methods of embedded structures
+// are promoted along with the methods of their own embedded structures,
+// so the latter are returned too, with selectors prefixed by the requested one.
+func (ps packages) embedded(pkg *reflect.Package, imp string, s *reflect.Struct, prefix string) ([]parent, error) {
+	return ps.embeddedVisit(pkg, imp, s, prefix, nil, map[string]bool{})
+}
 
+// embeddedVisit is an implementation of embedded that does not scan
+// the structures that are visited already. Structures embedded as pointers
+// the requested one is reached through are expected.
+func (ps packages) embeddedVisit(pkg *reflect.Package, imp string, s *reflect.Struct, prefix string, ptrs []parent, visited map[string]bool) (prs []parent, err error) {
+	k := imp + "." + s.Name
+	if visited[k] {
+		return
+	}
+	visited[k] = true
+	defer delete(visited, k)
+
+	for _, f := range s.Fields {
 		// Make sure current field is embedded anonymously,
 		// i.e. there is no arg name.
-		if pkg.Structs[i].Fields[j].Name != "" {
-			continue
-		}
-
-		// Ensure the struct is embedded as a pointer.
-		if !pkg.Structs[i].Fields[j].Type.Star {
+		if f.Name != "" {
 			continue
 		}
 
 		// Add the field to the list of results.
-		p, _ := pkg.Imports.Value(pkg.Structs[i].File, pkg.Structs[i].Fields[j].Type.Package)
-		prs = append(prs, parent{
-			Import: p,
-			Name:   pkg.Structs[i].Fields[j].Type.Name,
-		})
+		p := imp
+		if f.Type.Package != "" {
+			p, _ = pkg.Imports.Value(s.File, f.Type.Package)
+		}
+		pr := parent{
+			Import:   p,
+			Name:     f.Type.Name,
+			Field:    prefix + f.Type.Name,
+			Star:     f.Type.Star,
+			Tag:      f.Tag,
+			Args:     f.Type.Args,
+			Pointers: ptrs,
+		}
+		prs = append(prs, pr)
 
 		// Check whether this import has already been processed.
 		// If not, do it now.
 		if _, ok := ps[p]; p != "" && !ok {
-			ps.processPackage(p, routes.ParseTag(pkg.Structs[i].Fields[j].Tag))
+			if err := ps.processPackage(p, routes.ParseTag(f.Tag)); err != nil {
+				return nil, err
+			}
+		}
+
+		sp, ok := pkg, true
+		if p != imp {
+			sp, ok = strconv.LoadPackage(p)
+		}
+		if st, found := sp.Struct(&reflect.Type{Name: f.Type.Name}); ok && found {
+			sub := ptrs
+			if f.Type.Star {
+				sub = append(append([]parent{}, ptrs...), parent{Import: p, Name: pr.Name, Field: pr.Field})
+			}
+			sprs, err := ps.embeddedVisit(sp, p, st, prefix+f.Type.Name+".", sub, visited)
+			if err != nil {
+				return nil, err
+			}
+			prs = append(prs, sprs...)
+		}
+	}
+	return
+}
+
+// parents returns embedded structures of the controller that are controllers
+// themselves. Controllers embedded into other parent controllers are omitted
+// as they are handled by the handlers of the latter. Every parent
+// gets a unique ID that is used for generation of its import name.
+func (ps packages) parents(imp, name string) (cs []parent) {
+	for i, p := range ps[imp].data[name].Parents {
+		// Make sure it is a controller rather than just some embedded struct.
+		check := p.Import
+		if check == "" { // Embedded parent is a local structure.
+			check = imp
+		}
+		// Generic controllers are replaced by their instances, see instantiate.
+		if c, ok := ps[check].data[p.Name]; !ok || len(c.TypeParams) > 0 {
+			continue
+		}
+
+		// Make sure it is not embedded into another parent controller.
+		nested := false
+		for j := range cs {
+			if strings.HasPrefix(p.Field, cs[j].Field+".") {
+				nested = true
+				break
+			}
+		}
+		if nested {
+			continue
+		}
+
+		// It is a valid parent controller, add it to the list.
+		p.ID = i
+		cs = append(cs, p)
+	}
+	return
+}
+
+// roots returns sorted names of the controllers of the package that are
+// not parents of other controllers of the package or are parents of several ones.
+// Routes of other parents are returned along with the routes of the controller
+// that embeds them.
+func (ps packages) roots(imp string) (res []string) {
+	n := ps.embedders(imp)
+	for _, name := range ps[imp].names() {
+		if len(ps[imp].data[name].TypeParams) == 0 && n[name] != 1 {
+			res = append(res, name)
+		}
+	}
+	return
+}
+
+// shared returns names of the controllers of the package that are
+// parents of several controllers of the package. Their routes are
+// returned once rather than by every of the controllers that embed them.
+func (ps packages) shared(imp string) map[string]bool {
+	res := map[string]bool{}
+	for name, k := range ps.embedders(imp) {
+		if k > 1 {
+			res[name] = true
+		}
+	}
+	return res
+}
+
+// embedders returns the numbers of controllers of the package
+// the local parent controllers are embedded into.
+func (ps packages) embedders(imp string) map[string]int {
+	res := map[string]int{}
+	for name, c := range ps[imp].data {
+		if len(c.TypeParams) > 0 {
+			continue
+		}
+		for _, p := range ps.parents(imp, name) {
+			if p.Import == "" {
+				res[p.Name]++
+			}
+		}
+	}
+	return res
+}
+
+// declaration represents an action that is declared by a controller
+// or by one of its parents.
+type declaration struct {
+	Action  string // Name of the action, e.g. "Template".
+	Handler string // Controller that declares it, e.g. "github.com/user/app/controllers.Base.Template".
+	Field   string // Selector of the parent it is promoted from, e.g. "Base", empty for own actions.
+}
+
+// declarations returns actions of the controller and of its parents, recursively.
+// Selectors of the parents are prefixed by the requested one. Controllers that
+// are visited already are ignored, so embedding cycles do not cause infinite recursion.
+func (ps packages) declarations(imp, name, prefix string, visited map[string]bool) (ds []declaration) {
+	k := imp + "." + name
+	if visited[k] {
+		return
+	}
+	visited[k] = true
+	defer delete(visited, k)
+
+	for _, f := range ps[imp].data[name].Actions {
+		ds = append(ds, declaration{Action: f.Name, Handler: k + "." + f.Name, Field: prefix})
+	}
+	for _, p := range ps.parents(imp, name) {
+		pi := p.Import
+		if pi == "" { // Embedded parent is a local structure.
+			pi = imp
+		}
+		sel := p.Field
+		if prefix != "" {
+			sel = prefix + "." + sel
+		}
+		ds = append(ds, ps.declarations(pi, p.Name, sel, visited)...)
+	}
+	return
+}
+
+// promoted returns names of the actions of the controller, including
+// the ones promoted from its parents, mapped to selectors of the parents
+// they are promoted from, e.g. "Base.Template". Selectors of the controller's
+// own actions are empty. As in Go, actions of shallower parents shadow
+// the deeper ones. An error is returned if an action is declared by
+// different parents at the shallowest depth and thus is ambiguous.
+func (ps packages) promoted(imp, name string) (map[string]string, error) {
+	res, depths := map[string]string{}, map[string]int{}
+	ambiguous := map[string]string{}
+	for _, d := range ps.declarations(imp, name, "", map[string]bool{}) {
+		depth := 0
+		if d.Field != "" {
+			depth = strings.Count(d.Field, ".") + 1
+		}
+		switch prev, ok := depths[d.Action]; {
+		case !ok || depth < prev:
+			res[d.Action], depths[d.Action] = d.Field, depth
+			delete(ambiguous, d.Action)
+		case depth == prev && res[d.Action] != d.Field:
+			ambiguous[d.Action] = d.Field
+		}
+	}
+	if len(ambiguous) > 0 {
+		as := []string{}
+		for a := range ambiguous {
+			as = append(as, a)
+		}
+		sort.Strings(as)
+		return nil, fmt.Errorf(
+			`action "%s" of controller "%s.%s" is ambiguous: it is promoted from both "%s" and "%s"`,
+			as[0], imp, name, res[as[0]], ambiguous[as[0]],
+		)
+	}
+	return res, nil
+}
+
+// shadowed returns handlers of the actions that are declared by the parents
+// of the controller (see parents) but are shadowed by the controller's own
+// actions or by the actions of its shallower parents, e.g.
+// "github.com/user/app/controllers.Base.Index". The handlers are mapped
+// to IDs of the parents they are declared by, so routes of the latter
+// can omit them.
+func (ps packages) shadowed(imp, name string) (map[int][]string, error) {
+	as, err := ps.promoted(imp, name)
+	if err != nil {
+		return nil, err
+	}
+	res := map[int][]string{}
+	for _, p := range ps.parents(imp, name) {
+		pi := p.Import
+		if pi == "" { // Embedded parent is a local structure.
+			pi = imp
+		}
+		added := map[string]bool{}
+		for _, d := range ps.declarations(pi, p.Name, p.Field, map[string]bool{}) {
+			if as[d.Action] != d.Field && !added[d.Handler] {
+				res[p.ID] = append(res[p.ID], d.Handler)
+				added[d.Handler] = true
+			}
+		}
+	}
+	return res, nil
+}
+
+// pointers returns structures embedded as pointers that parents of
+// the controller (see parents) are promoted through and that are not
+// controllers themselves. They are allocated by the generated handlers
+// before the parents are initialized.
+func (ps packages) pointers(imp, name string) (res []parent) {
+	added := map[string]bool{}
+	for _, p := range ps.parents(imp, name) {
+		for _, ptr := range p.Pointers {
+			if !added[ptr.Field] {
+				res = append(res, ptr)
+				added[ptr.Field] = true
+			}
 		}
 	}
 	return
@@ -247,7 +815,7 @@ func (ps packages) extractInitFunc(pkg *reflect.Package) *reflect.Func {
 
 // extractControllers gets a reflect.Package type and returns
 // a slice of controllers that are found there.
-func (ps packages) extractControllers(pkg *reflect.Package, prefs routes.Prefixes) controllers {
+func (ps packages) extractControllers(pkg *reflect.Package, prefs routes.Prefixes) (controllers, error) {
 	// Initialize function that will be used for detection of actions.
 	action := a.Func(pkg)
 
@@ -277,36 +845,214 @@ func (ps packages) extractControllers(pkg *reflect.Package, prefs routes.Prefixe
 				return true
 			}
 
-			// Parse action's routes.
+			// Parse action's routes. Routes of generic controllers
+			// are parsed when they are instantiated.
+			if len(pkg.Structs[i].TypeParams) > 0 {
+				return true
+			}
 			if r := prefs.ParseRoutes(pkg.Structs[i].Name, f); len(r) > 0 {
 				rs = append(rs, r)
 			}
 			return true
-		}, a.Regular, a.After, a.Before)
+		}, a.Regular, a.After, a.Before, a.OnError, a.Recover, a.Finally, a.Reset)
 
 		// If there are no any, this is not a controller; ignore it.
-		if count == 0 {
+		// Magic Finally and Reset methods do not make a structure a controller
+		// as such methods are common for types of any kind.
+		if count == len(as[5])+len(as[6]) {
+			continue
+		}
+
+		// Parse middleware of the controller. If they are incorrect,
+		// the controller is ignored rather than served without them.
+		uses, err := a.Uses(pkg, pkg.Structs[i].File, pkg.Structs[i].Comments)
+		if err != nil {
+			log.Warn.Printf(`Structure "%s" cannot be treated as controller: %v.`, pkg.Structs[i].Name, err)
 			continue
 		}
 
 		// Parse parent controllers and fields that require binding.
-		fs, prs := ps.scanFields(pkg, i)
+		fs, prs, err := ps.scanFields(pkg, i)
+		if err != nil {
+			return controllers{}, err
+		}
 
 		// Add a new controller to the list of results.
 		cs.data[pkg.Structs[i].Name] = controller{
 			Actions: as[0],
 			After:   firstFunc(as[1]),
 			Before:  firstFunc(as[2]),
+			OnError: firstFunc(as[3]),
+			Recover: firstFunc(as[4]),
+			Finally: firstFunc(as[5]),
+			Reset:   firstFunc(as[6]),
 
 			Comments: pkg.Structs[i].Comments,
+			Uses:     uses,
 			File:     pkg.Structs[i].File,
 			Parents:  prs,
 
 			Fields: fs,
 			Routes: rs,
+
+			TypeParams: pkg.Structs[i].TypeParams,
+
+			pkg: pkg,
+		}
+	}
+	return cs, ps.instantiate(pkg, prefs, cs)
+}
+
+// instantiate adds instances of the generic controllers to the list for every
+// concrete structure that embeds them, e.g. "ResourceUser" in case of "*Resource[User]".
+// The structure does not have to be a controller itself, but if it is, its parents
+// are made to refer to the instances. Only local generic controllers are instantiated
+// and type arguments must be local or predeclared types. Route prefixes
+// of the instances are taken from the tags of the embedded fields.
+func (ps packages) instantiate(pkg *reflect.Package, prefs routes.Prefixes, cs controllers) error {
+	action := a.Func(pkg)
+	for i := range pkg.Structs {
+		// Embedded structures of the generic ones may refer to their type parameters,
+		// so only concrete structures are scanned.
+		if len(pkg.Structs[i].TypeParams) > 0 {
+			continue
+		}
+		name := pkg.Structs[i].Name
+		prs := cs.data[name].Parents
+		if _, ok := cs.data[name]; !ok {
+			var err error
+			if prs, err = ps.embedded(pkg, "", &pkg.Structs[i], ""); err != nil {
+				return err
+			}
 		}
+	parents:
+		for k, p := range prs {
+			if len(p.Args) == 0 {
+				continue
+			}
+			if p.Import != "" {
+				if g, ok := ps[p.Import].data[p.Name]; ok && len(g.TypeParams) > 0 {
+					log.Warn.Printf(
+						`Parent "%s" of "%s" is ignored: generic controllers must be declared in the same package.`,
+						p.Field, name,
+					)
+				}
+				continue
+			}
+			g, ok := cs.data[p.Name]
+			if !ok || len(g.TypeParams) == 0 {
+				continue
+			}
+
+			// Make sure type arguments are supported.
+			args := []string{}
+			for _, t := range p.Args {
+				arg, ok := typeArg(pkg, t)
+				if !ok {
+					log.Warn.Printf(
+						`Parent "%s" of "%s" is ignored: type argument "%s" is neither a local nor a predeclared type.`,
+						p.Field, name, t,
+					)
+					args = nil
+					break
+				}
+				args = append(args, arg)
+			}
+			if len(args) != len(g.TypeParams) {
+				continue
+			}
+
+			// Check whether the instance exists already.
+			n, typ := instanceName(p.Name, p.Args), fmt.Sprintf("%s[%s]", p.Name, strings.Join(args, ", "))
+			if inst, ok := cs.data[n]; ok {
+				if inst.Instance != typ {
+					log.Warn.Printf(`Parent "%s" of "%s" is ignored: "%s" is declared already.`, p.Field, name, n)
+					continue
+				}
+				prs[k].Name = n
+				continue
+			}
+
+			// Instantiate actions and magic methods of the generic controller.
+			// If some of their types cannot be instantiated, the parent is ignored.
+			pfs := routes.ParseTag(p.Tag)
+			if len(pfs) == 0 {
+				pfs = prefs
+			}
+			inst := g
+			inst.TypeParams, inst.Instance = nil, typ
+			inst.Actions, inst.Routes = nil, [][]routes.Route{}
+			for i := range g.Actions {
+				f, err := g.Actions[i].Instantiate(p.Args)
+				if err != nil {
+					log.Warn.Printf(`Parent "%s" of "%s" is ignored: %v.`, p.Field, name, err)
+					continue parents
+				}
+				if !action(&f) {
+					continue
+				}
+				inst.Actions = append(inst.Actions, f)
+				if r := pfs.ParseRoutes(n, &f); len(r) > 0 {
+					inst.Routes = append(inst.Routes, r)
+				}
+			}
+			for _, m := range []**reflect.Func{&inst.After, &inst.Before, &inst.OnError, &inst.Recover, &inst.Finally, &inst.Reset} {
+				if *m == nil {
+					continue
+				}
+				f, err := (*m).Instantiate(p.Args)
+				if err != nil {
+					log.Warn.Printf(`Parent "%s" of "%s" is ignored: %v.`, p.Field, name, err)
+					continue parents
+				}
+				if action(&f) {
+					*m = &f
+				} else {
+					*m = nil
+				}
+			}
+			cs.data[n] = inst
+			prs[k].Name = n
+		}
+	}
+	return nil
+}
+
+// typeArg returns code of the type argument of a generic controller's instance,
+// e.g. "*contr.User" or "[]int", and true. If the type is neither
+// a local nor a predeclared one, false is returned.
+func typeArg(pkg *reflect.Package, t *reflect.Type) (string, bool) {
+	if t.Package != "" || len(t.Args) > 0 {
+		return "", false
+	}
+	b := t.Base()
+	if types.Universe.Lookup(b) == nil {
+		_, isStruct := pkg.Struct(&reflect.Type{Name: b})
+		if _, isType := pkg.Types[b]; !isStruct && !isType {
+			return "", false
+		}
+		b = "contr." + b
 	}
-	return cs
+	s := t.Name[:len(t.Name)-len(t.Base())] + b
+	if t.Star {
+		s = "*" + s
+	}
+	return s, true
+}
+
+// instanceName returns a name of the generic controller's instance,
+// e.g. "ResourceUser" in case of "Resource[User]" or "PairIntPtrUser"
+// in case of "Pair[int, *User]".
+func instanceName(name string, args []*reflect.Type) string {
+	for _, t := range args {
+		if t.Star {
+			name += "Ptr"
+		}
+		name += strings.Repeat("Slice", strings.Count(t.Name, "[]"))
+		b := t.Base()
+		name += strings.ToUpper(b[:1]) + b[1:]
+	}
+	return name
 }
 
 // firstFunc gets a list of functions and returns the first element of it.
@@ -317,3 +1063,23 @@ func firstFunc(fs reflect.Funcs) *reflect.Func {
 	}
 	return &fs[0]
 }
+
+// imports returns sorted import paths of the packages.
+func (ps packages) imports() []string {
+	res := []string{}
+	for imp := range ps {
+		res = append(res, imp)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// names returns sorted names of the controllers.
+func (cs controllers) names() []string {
+	res := []string{}
+	for n := range cs.data {
+		res = append(res, n)
+	}
+	sort.Strings(res)
+	return res
+}
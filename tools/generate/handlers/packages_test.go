@@ -5,6 +5,7 @@ import (
 	r "reflect"
 	"testing"
 
+	a "github.com/goaltools/goal/internal/action"
 	"github.com/goaltools/goal/internal/log"
 	"github.com/goaltools/goal/internal/reflect"
 	"github.com/goaltools/goal/internal/routes"
@@ -12,15 +13,37 @@ import (
 
 func TestProcessPackage(t *testing.T) {
 	psR := packages{}
-	psR.processPackage("github.com/goaltools/goal/tools/generate/handlers/testdata/controllers", routes.Prefixes{
+	err := psR.processPackage("github.com/goaltools/goal/tools/generate/handlers/testdata/controllers", routes.Prefixes{
 		{
 			Method:  "ROUTE",
 			Pattern: "",
 		},
 	})
+	if err != nil {
+		t.Fatal(err)
+	}
 	assertDeepEqualPkgs(ps, psR)
 }
 
+func TestControllerBody(t *testing.T) {
+	c := ps["github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"].data["App"]
+	if !c.HasBody() {
+		t.Errorf("Controller App has an action that expects a request body.")
+	}
+	for _, f := range c.Actions {
+		b := c.Body(&f)
+		if f.Name != "Create" {
+			if b != nil {
+				t.Errorf(`Action "%s" does not expect a request body, got %#v.`, f.Name, b)
+			}
+			continue
+		}
+		if b == nil || !c.IsBody(&f, f.Params[0]) || c.IsBody(&f, f.Params[1]) {
+			t.Errorf(`Parameter "input" of "%s" is expected to be a request body, got %#v.`, f.Name, b)
+		}
+	}
+}
+
 func TestParentPackage(t *testing.T) {
 	p := parent{}
 	s := p.Package()
@@ -45,11 +68,237 @@ func TestParentPackage(t *testing.T) {
 
 func TestControllerIgnoredArgs(t *testing.T) {
 	c := controller{}
-	a := ps["github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"].data["App"].Actions[0]
+	var a reflect.Func
+	for _, f := range ps["github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"].data["App"].Actions {
+		if f.Name == "HelloWorld" {
+			a = f
+		}
+	}
 	exp := ", _, _"
 	if r := c.IgnoredArgs(&a); r != exp {
 		t.Errorf(`Incorrect IgnoreArgs result. Expected "%s", got "%s".`, exp, r)
 	}
+	exp = ", _, err"
+	if r := c.Results(&a); r != exp || !c.ReturnsError(&a) {
+		t.Errorf(`Incorrect Results result. Expected "%s", got "%s".`, exp, r)
+	}
+}
+
+func TestControllerHandler(t *testing.T) {
+	psR := packages{}
+	if err := psR.processPackage("github.com/goaltools/goal/tools/generate/handlers/testdata/controllers", routes.NewPrefixes()); err != nil {
+		t.Fatal(err)
+	}
+	c := psR["github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"].data["App"]
+	imps := map[string]string{"github.com/goaltools/goal/tools/generate/handlers/testdata/controllers/subpackage": "i0"}
+	for _, v := range []struct {
+		handler, exp string
+	}{
+		{"App.Secret", "i0.Auth(http.HandlerFunc(App.Secret)).ServeHTTP"},
+		{"App.Search", "App.Search"},
+	} {
+		if h, err := c.Handler(imps, routes.Route{HandlerName: v.handler}); err != nil || h != v.exp {
+			t.Errorf(`Expected "%s", got "%s", %v.`, v.exp, h, err)
+		}
+	}
+	if h, exp := c.Wrap(imps, "rs[i].Handler"), "contr.Logged(rs[i].Handler)"; h != exp {
+		t.Errorf(`Expected "%s", got "%s".`, exp, h)
+	}
+}
+
+func TestControllerCollision(t *testing.T) {
+	c := controller{Actions: reflect.Funcs{{Name: "Index"}, {Name: "Finally"}}}
+	if err := c.collision("App", []string{"New"}); err != nil {
+		t.Errorf("No collisions expected, got %v.", err)
+	}
+	if err := c.collision("App", helpers); err == nil {
+		t.Errorf(`Error expected as "Finally" action collides with a helper method.`)
+	}
+
+	c.Actions[1].Name = "Reset"
+	if err := c.collision("App", helpers); err != nil {
+		t.Errorf("No collisions expected unless controllers are pooled, got %v.", err)
+	}
+	if err := c.collision("App", poolHelpers); err == nil {
+		t.Errorf(`Error expected as "Reset" action collides with a helper method of pooled controllers.`)
+	}
+}
+
+func TestControllerSkip(t *testing.T) {
+	psR := packages{}
+	if err := psR.processPackage(
+		"github.com/goaltools/goal/tools/generate/handlers/testdata/controllers",
+		routes.Prefixes{{Method: "GET", Pattern: "/"}},
+	); err != nil {
+		t.Fatal(err)
+	}
+	imp := "github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"
+	c := psR[imp].data["App"]
+	for i := range c.Actions {
+		if c.Actions[i].Name != "Health" {
+			continue
+		}
+		s, err := c.Skip(imp, &c.Actions[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []string{imp + "/subpackage.Controller"}
+		if s.Before || !s.After || !r.DeepEqual(s.Parents, exp) {
+			t.Errorf("Incorrect skipped magic methods of Health: %#v.", s)
+		}
+	}
+
+	for ctr, exp := range map[string]bool{
+		imp + ".App":                        true,
+		imp + ".Controller":                 true,
+		imp + "/subpackage.Controller":      true,
+		imp + ".NotController":              false,
+		"github.com/goaltools/goal.Unknown": false,
+	} {
+		if res := psR.embeds(imp, "App", ctr, map[string]bool{}); res != exp {
+			t.Errorf(`"%s": expected %v, got %v.`, ctr, exp, res)
+		}
+	}
+}
+
+func TestProcessPackage_MagicMethodsOnly(t *testing.T) {
+	psR := packages{}
+	imp := "github.com/goaltools/goal/tools/generate/handlers/testdata/controllers"
+	if err := psR.processPackage(imp, routes.NewPrefixes()); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"counter", "cleanup"} {
+		if _, ok := psR[imp].data[name]; ok {
+			t.Errorf(`"%s" has no actions, it is not expected to be a controller.`, name)
+		}
+	}
+}
+
+func TestPackagesPromoted(t *testing.T) {
+	fns := func(names ...string) (fs reflect.Funcs) {
+		for _, n := range names {
+			fs = append(fs, reflect.Func{Name: n})
+		}
+		return
+	}
+	psR := packages{
+		"app": controllers{
+			data: map[string]controller{
+				"App": {
+					Actions: fns("Index"),
+					Parents: []parent{
+						{Name: "A", Field: "A", Star: true},
+						{Name: "Base", Field: "Base"},
+						{Name: "B", Field: "Base.B"},
+						{Import: "lib", Name: "C", Field: "C"},
+					},
+				},
+				"Ambiguous": {
+					Parents: []parent{
+						{Name: "A", Field: "A"},
+						{Name: "B", Field: "B"},
+					},
+				},
+				"Resolved": {
+					Actions: fns("Show"),
+					Parents: []parent{
+						{Name: "Ambiguous", Field: "Ambiguous", Star: true},
+						{Name: "A", Field: "Ambiguous.A"},
+						{Name: "B", Field: "Ambiguous.B"},
+					},
+				},
+				"Deep": {
+					Parents: []parent{
+						{Name: "Ptr", Field: "Ptr", Star: true},
+						{Name: "A", Field: "Ptr.A", Pointers: []parent{{Name: "Ptr", Field: "Ptr"}}},
+					},
+				},
+				"A": {Actions: fns("List", "Show")},
+				"B": {Actions: fns("Show", "Edit")},
+			},
+		},
+		"lib": controllers{
+			data: map[string]controller{
+				"C": {Actions: fns("Edit")},
+			},
+		},
+	}
+
+	exp := []parent{
+		{ID: 0, Name: "A", Field: "A", Star: true},
+		{ID: 2, Name: "B", Field: "Base.B"},
+		{ID: 3, Import: "lib", Name: "C", Field: "C"},
+	}
+	if cs := psR.parents("app", "App"); !r.DeepEqual(cs, exp) {
+		t.Errorf("Incorrect parent controllers. Expected %#v, got %#v.", exp, cs)
+	}
+
+	if rs, exp := psR.roots("app"), []string{"A", "App", "B", "Deep", "Resolved"}; !r.DeepEqual(rs, exp) {
+		t.Errorf("Incorrect root controllers. Expected %v, got %v.", exp, rs)
+	}
+	if sh, exp := psR.shared("app"), map[string]bool{"A": true, "B": true}; !r.DeepEqual(sh, exp) {
+		t.Errorf("Incorrect shared parent controllers. Expected %v, got %v.", exp, sh)
+	}
+
+	expAs := map[string]string{"Index": "", "List": "A", "Show": "A", "Edit": "C"}
+	if as, err := psR.promoted("app", "App"); err != nil || !r.DeepEqual(as, expAs) {
+		t.Errorf("Incorrect promoted actions. Expected %v, got %v, %v.", expAs, as, err)
+	}
+	if _, err := psR.promoted("app", "Ambiguous"); err == nil {
+		t.Errorf(`Error expected as "Show" action is ambiguous.`)
+	}
+
+	// Actions of the deeper parents are shadowed even if they are ambiguous.
+	expAs = map[string]string{"Show": "", "List": "Ambiguous.A", "Edit": "Ambiguous.B"}
+	if as, err := psR.promoted("app", "Resolved"); err != nil || !r.DeepEqual(as, expAs) {
+		t.Errorf("Incorrect promoted actions. Expected %v, got %v, %v.", expAs, as, err)
+	}
+
+	expSh := map[int][]string{2: {"app.B.Show", "app.B.Edit"}}
+	if sh, err := psR.shadowed("app", "App"); err != nil || !r.DeepEqual(sh, expSh) {
+		t.Errorf("Incorrect shadowed handlers. Expected %v, got %v, %v.", expSh, sh, err)
+	}
+	expSh = map[int][]string{0: {"app.A.Show", "app.B.Show"}}
+	if sh, err := psR.shadowed("app", "Resolved"); err != nil || !r.DeepEqual(sh, expSh) {
+		t.Errorf("Incorrect shadowed handlers. Expected %v, got %v, %v.", expSh, sh, err)
+	}
+
+	expPs := []parent{{Name: "Ptr", Field: "Ptr"}}
+	if ps := psR.pointers("app", "Deep"); !r.DeepEqual(ps, expPs) {
+		t.Errorf("Incorrect pointers. Expected %v, got %v.", expPs, ps)
+	}
+}
+
+func TestTypeArg(t *testing.T) {
+	pkg := &reflect.Package{
+		Structs: []reflect.Struct{{Name: "User"}},
+		Types:   reflect.Types{"UserID": {Name: "int64"}},
+	}
+	for _, v := range []struct {
+		typ      reflect.Type
+		exp, exn string
+	}{
+		{reflect.Type{Name: "User", Star: true}, "*contr.User", "ResourcePtrUser"},
+		{reflect.Type{Name: "[]UserID"}, "[]contr.UserID", "ResourceSliceUserID"},
+		{reflect.Type{Name: "int"}, "int", "ResourceInt"},
+	} {
+		if res, ok := typeArg(pkg, &v.typ); !ok || res != v.exp {
+			t.Errorf(`"%s": expected "%s", got "%s", %v.`, v.typ.String(), v.exp, res, ok)
+		}
+		if n := instanceName("Resource", []*reflect.Type{&v.typ}); n != v.exn {
+			t.Errorf(`"%s": expected instance name "%s", got "%s".`, v.typ.String(), v.exn, n)
+		}
+	}
+
+	for _, typ := range []reflect.Type{
+		{Name: "Unknown"},
+		{Name: "Time", Package: "time"},
+		{Name: "Resource", Args: []*reflect.Type{{Name: "int"}}},
+	} {
+		if _, ok := typeArg(pkg, &typ); ok {
+			t.Errorf(`"%s" is not expected to be a supported type argument.`, typ.String())
+		}
+	}
 }
 
 func assertDeepEqualController(c1, c2 *controller) {
@@ -70,6 +319,9 @@ func assertDeepEqualController(c1, c2 *controller) {
 	if !r.DeepEqual(c1.Parents, c2.Parents) {
 		log.Error.Panicf("Controllers have different parent controllers: %#v != %#v.", c1.Parents, c2.Parents)
 	}
+	if !r.DeepEqual(c1.Uses, c2.Uses) {
+		log.Error.Panicf("Controllers have different middleware: %#v != %#v.", c1.Uses, c2.Uses)
+	}
 	log.Trace.Println("Actions...")
 	if err := reflect.AssertEqualFuncs(c1.Actions, c2.Actions); err != nil {
 		log.Error.Panic(err)
@@ -82,6 +334,28 @@ func assertDeepEqualController(c1, c2 *controller) {
 	if err := reflect.AssertEqualFunc(c1.After, c2.After); err != nil {
 		log.Error.Panic(err)
 	}
+	log.Trace.Println("OnError...")
+	if err := reflect.AssertEqualFunc(c1.OnError, c2.OnError); err != nil {
+		log.Error.Panic(err)
+	}
+	log.Trace.Println("Recover...")
+	if err := reflect.AssertEqualFunc(c1.Recover, c2.Recover); err != nil {
+		log.Error.Panic(err)
+	}
+	log.Trace.Println("Finally...")
+	if err := reflect.AssertEqualFunc(c1.Finally, c2.Finally); err != nil {
+		log.Error.Panic(err)
+	}
+	log.Trace.Println("Reset...")
+	if err := reflect.AssertEqualFunc(c1.Reset, c2.Reset); err != nil {
+		log.Error.Panic(err)
+	}
+	if !r.DeepEqual(c1.TypeParams, c2.TypeParams) || c1.Instance != c2.Instance {
+		log.Error.Panicf(
+			"Controllers have different type parameters or instances: %v, %s != %v, %s.",
+			c1.TypeParams, c1.Instance, c2.TypeParams, c2.Instance,
+		)
+	}
 	log.Trace.Println("Fields...")
 	if !r.DeepEqual(c1.Fields, c2.Fields) {
 		log.Error.Panicf(`Fields %v and %v are not equal.`, c1.Fields, c2.Fields)
@@ -130,6 +404,15 @@ func assertDeepEqualPkgs(ps1, ps2 packages) {
 	}
 }
 
+var resourceRecv = &reflect.Arg{
+	Name: "c",
+	Type: &reflect.Type{
+		Name: "Resource",
+		Star: true,
+		Args: []*reflect.Type{{Name: "T"}},
+	},
+}
+
 var ps = packages{
 	"github.com/goaltools/goal/tools/generate/handlers/testdata/controllers": controllers{
 		data: map[string]controller{
@@ -176,14 +459,24 @@ var ps = packages{
 						},
 					},
 					{
-						Comments: []string{"// Index is a sample action."},
-						File:     "init.go",
-						Name:     "Index",
+						Comments: []string{
+							"// Register is an action with parameters of local structure types",
+							"// that are bound from the form.",
+						},
+						File: "app.go",
+						Name: "Register",
 						Params: []reflect.Arg{
 							{
-								Name: "page",
+								Name: "u",
 								Type: &reflect.Type{
-									Name: "int",
+									Name: "UserForm",
+								},
+							},
+							{
+								Name: "addr",
+								Type: &reflect.Type{
+									Name: "Address",
+									Star: true,
 								},
 							},
 						},
@@ -191,101 +484,835 @@ var ps = packages{
 							Name: "c",
 							Type: &reflect.Type{
 								Name: "App",
-								Star: true,
 							},
 						},
 						Results: []reflect.Arg{
 							{
 								Type: &reflect.Type{
 									Name:    "Handler",
-									Package: "h",
+									Package: "http",
 								},
 							},
 						},
 					},
-				},
-
-				Routes: [][]routes.Route{
-					{
-						{Method: "GET", Pattern: "/App/HelloWorld", HandlerName: "App.HelloWorld"},
-					},
-				},
-				Comments: []string{
-					"// App is a sample controller.",
-				},
-				File: "app.go",
-				Parents: []parent{
-					{
-						Name: "Controller",
-					},
 					{
-						Name: "NotController",
-					},
-					{
-						Name: "NotController1",
-					},
-				},
-			},
-			"Controller": {
-				After: &reflect.Func{
-					Comments: []string{"// After is a magic method that is executed after every request."},
-					File:     "init.go",
-					Name:     "After",
-					Params: []reflect.Arg{
-						{
-							Name: "name",
+						Comments: []string{
+							"// Create is an action that expects a JSON or XML request body.",
+							"//@post /users",
+							"//@body input",
+						},
+						File: "app.go",
+						Name: "Create",
+						Params: []reflect.Arg{
+							{
+								Name: "input",
+								Type: &reflect.Type{
+									Name: "UserForm",
+									Star: true,
+								},
+							},
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
 							Type: &reflect.Type{
-								Name: "string",
+								Name: "App",
 							},
 						},
-					},
-					Recv: &reflect.Arg{
-						Name: "c",
-						Type: &reflect.Type{
-							Name: "Controller",
-							Star: true,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
 						},
 					},
-					Results: []reflect.Arg{
-						{
-							Type: &reflect.Type{
-								Name:    "Handler",
-								Package: "h",
+					{
+						Comments: []string{"// Show is an action with parameters of named types."},
+						File:     "app.go",
+						Name:     "Show",
+						Params: []reflect.Arg{
+							{
+								Name: "id",
+								Type: &reflect.Type{
+									Name: "UserID",
+								},
+							},
+							{
+								Name: "slug",
+								Type: &reflect.Type{
+									Name: "Slug",
+								},
+							},
+							{
+								Name: "code",
+								Type: &reflect.Type{
+									Name: "Code",
+								},
+							},
+							{
+								Name: "n",
+								Type: &reflect.Type{
+									Name:    "Int",
+									Package: "big",
+								},
 							},
 						},
-					},
-				},
-				Before: &reflect.Func{
-					Comments: []string{"// Before is a magic method that is executed before every request."},
-					File:     "init.go",
-					Name:     "Before",
-					Params: []reflect.Arg{
-						{
-							Name: "uid",
+						Recv: &reflect.Arg{
+							Name: "c",
 							Type: &reflect.Type{
-								Name: "string",
+								Name: "App",
 							},
 						},
-					},
-					Recv: &reflect.Arg{
-						Name: "c",
-						Type: &reflect.Type{
-							Name: "Controller",
-							Star: true,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
 						},
 					},
-					Results: []reflect.Arg{
-						{
+					{
+						Comments: []string{
+							"// Report is an action with time parameters.",
+							"//@get",
+							"//@layout to 02.01.2006",
+						},
+						File: "app.go",
+						Name: "Report",
+						Params: []reflect.Arg{
+							{
+								Name: "from",
+								Type: &reflect.Type{
+									Name:    "Time",
+									Package: "time",
+								},
+							},
+							{
+								Name: "to",
+								Type: &reflect.Type{
+									Name:    "Time",
+									Package: "time",
+								},
+							},
+							{
+								Name: "every",
+								Type: &reflect.Type{
+									Name:    "Duration",
+									Package: "time",
+								},
+							},
+							{
+								Name: "p",
+								Type: &reflect.Type{
+									Name: "Period",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
 							Type: &reflect.Type{
-								Name:    "Handler",
-								Package: "h",
+								Name: "App",
 							},
 						},
-					},
-				},
-				Fields: []field{
-					{
-						Name: "R",
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{"// Export is an action that expects the request's context."},
+						File:     "app.go",
+						Name:     "Export",
+						Params: []reflect.Arg{
+							{
+								Name: "ctx",
+								Type: &reflect.Type{
+									Name:    "Context",
+									Package: "context",
+								},
+							},
+							{
+								Name: "format",
+								Type: &reflect.Type{
+									Name: "string",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+							{
+								Type: &reflect.Type{
+									Name: "error",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Profile is an action whose parameters are read from different sources.",
+							"//@post",
+							"//@param token header:X-Auth-Token",
+							"//@param sid cookie:session",
+							"//@param page query",
+							"//@param id path",
+							"//@validate token required",
+						},
+						File: "app.go",
+						Name: "Profile",
+						Params: []reflect.Arg{
+							{
+								Name: "token",
+								Type: &reflect.Type{
+									Name: "string",
+								},
+							},
+							{
+								Name: "sid",
+								Type: &reflect.Type{
+									Name: "string",
+								},
+							},
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+							{
+								Name: "id",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Search is an action with validated parameters.",
+							"//@get",
+							"//@validate q required",
+							"//@validate page min=1 max=100",
+						},
+						File: "app.go",
+						Name: "Search",
+						Params: []reflect.Arg{
+							{
+								Name: "q",
+								Type: &reflect.Type{
+									Name: "string",
+								},
+							},
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Secret is an action that is wrapped with a middleware.",
+							"//@get",
+							"//@use subpackage.Auth",
+						},
+						File: "init.go",
+						Name: "Secret",
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+								Star: true,
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "h",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Health is an action that omits magic methods of the parent controllers.",
+							"//@get",
+							"//@skip subpackage.Controller",
+							"//@skip After",
+						},
+						File: "init.go",
+						Name: "Health",
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+								Star: true,
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "h",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{"// Index is a sample action."},
+						File:     "init.go",
+						Name:     "Index",
+						Params: []reflect.Arg{
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "App",
+								Star: true,
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "h",
+								},
+							},
+						},
+					},
+				},
+
+				Finally: &reflect.Func{
+					Comments: []string{
+						"// Finally is a magic method of App that is executed at the very end of every request.",
+						"// It shadows Finally method of the embedded Controller.",
+					},
+					File: "app.go",
+					Name: "Finally",
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "App",
+							Star: true,
+						},
+					},
+				},
+
+				Reset: &reflect.Func{
+					Comments: []string{
+						"// Reset is a magic method of App that is executed before",
+						"// the controller is reused by the handlers generated with --pool.",
+					},
+					File: "app.go",
+					Name: "Reset",
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "App",
+							Star: true,
+						},
+					},
+				},
+
+				Routes: [][]routes.Route{
+					{
+						{Method: "GET", Pattern: "/App/HelloWorld", HandlerName: "App.HelloWorld"},
+					},
+					{
+						{Method: "POST", Pattern: "/users", HandlerName: "App.Create"},
+					},
+					{
+						{Method: "GET", Pattern: "/App/Report", HandlerName: "App.Report"},
+					},
+					{
+						{Method: "GET", Pattern: "/App/Search", HandlerName: "App.Search"},
+					},
+					{
+						{Method: "POST", Pattern: "/App/Profile", HandlerName: "App.Profile"},
+					},
+					{
+						{Method: "GET", Pattern: "/App/Secret", HandlerName: "App.Secret"},
+					},
+					{
+						{Method: "GET", Pattern: "/App/Health", HandlerName: "App.Health"},
+					},
+				},
+				Comments: []string{
+					"// App is a sample controller.",
+					"//@use Logged",
+				},
+				Uses: []a.Middleware{
+					{Name: "Logged"},
+				},
+				File: "app.go",
+				Parents: []parent{
+					{
+						Name:  "Controller",
+						Field: "Controller",
+						Star:  true,
+					},
+					{
+						Import: "github.com/goaltools/goal/tools/generate/handlers/testdata/controllers/subpackage",
+						Name:   "Controller",
+						Field:  "Controller.Controller",
+						Star:   true,
+						Tag:    `@route:"/subpackage"`,
+						Pointers: []parent{
+							{Name: "Controller", Field: "Controller"},
+						},
+					},
+					{
+						Import: "github.com/naoina/denco",
+						Name:   "Param",
+						Field:  "Controller.Param",
+						Star:   true,
+						Pointers: []parent{
+							{Name: "Controller", Field: "Controller"},
+						},
+					},
+					{
+						Import: "testing",
+						Name:   "M",
+						Field:  "Controller.M",
+						Pointers: []parent{
+							{Name: "Controller", Field: "Controller"},
+						},
+					},
+					{
+						Name:  "NotController",
+						Field: "NotController",
+						Star:  true,
+					},
+					{
+						Name:  "NotController1",
+						Field: "NotController1",
+						Star:  true,
+					},
+					{
+						Name:  "Base",
+						Field: "Base",
+					},
+					{
+						Name:  "Pager",
+						Field: "Base.Pager",
+					},
+				},
+			},
+			"Resource": {
+				Actions: []reflect.Func{
+					{
+						Comments: []string{"// List is an action of Resource.", "//@get"},
+						File:     "generic.go",
+						Name:     "List",
+						Params: []reflect.Arg{
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: resourceRecv,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Create is an action whose parameter is of the type parameter's type.",
+							"//@post",
+							"//@body item",
+						},
+						File: "generic.go",
+						Name: "Create",
+						Params: []reflect.Arg{
+							{
+								Name: "item",
+								Type: &reflect.Type{
+									Name: "T",
+								},
+							},
+						},
+						Recv: resourceRecv,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+				},
+				Before: &reflect.Func{
+					Comments: []string{"// Before is a magic method of Resource."},
+					File:     "generic.go",
+					Name:     "Before",
+					Recv:     resourceRecv,
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "http",
+							},
+						},
+					},
+				},
+				Fields: []field{
+					{
+						Name: "W",
+						Type: "response",
+					},
+				},
+
+				Comments: []string{
+					"// Resource is a generic controller, its actions and magic methods",
+					"// are instantiated for every concrete structure that embeds it.",
+				},
+				File: "generic.go",
+
+				TypeParams: []string{"T"},
+			},
+			"ResourceUserForm": {
+				Actions: []reflect.Func{
+					{
+						Comments: []string{"// List is an action of Resource.", "//@get"},
+						File:     "generic.go",
+						Name:     "List",
+						Params: []reflect.Arg{
+							{
+								Name: "page",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: resourceRecv,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+					{
+						Comments: []string{
+							"// Create is an action whose parameter is of the type parameter's type.",
+							"//@post",
+							"//@body item",
+						},
+						File: "generic.go",
+						Name: "Create",
+						Params: []reflect.Arg{
+							{
+								Name: "item",
+								Type: &reflect.Type{
+									Name: "UserForm",
+								},
+							},
+						},
+						Recv: resourceRecv,
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+				},
+				Before: &reflect.Func{
+					Comments: []string{"// Before is a magic method of Resource."},
+					File:     "generic.go",
+					Name:     "Before",
+					Recv:     resourceRecv,
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "http",
+							},
+						},
+					},
+				},
+				Fields: []field{
+					{
+						Name: "W",
+						Type: "response",
+					},
+				},
+
+				Comments: []string{
+					"// Resource is a generic controller, its actions and magic methods",
+					"// are instantiated for every concrete structure that embeds it.",
+				},
+				File: "generic.go",
+				Routes: [][]routes.Route{
+					{
+						{Method: "GET", Pattern: "/users/ResourceUserForm/List", HandlerName: "ResourceUserForm.List"},
+					},
+					{
+						{Method: "POST", Pattern: "/users/ResourceUserForm/Create", HandlerName: "ResourceUserForm.Create"},
+					},
+				},
+
+				Instance: "Resource[contr.UserForm]",
+			},
+			"Pager": {
+				Actions: []reflect.Func{
+					{
+						Comments: []string{"// Page is an action of Pager that is promoted to App."},
+						File:     "app.go",
+						Name:     "Page",
+						Params: []reflect.Arg{
+							{
+								Name: "n",
+								Type: &reflect.Type{
+									Name: "int",
+								},
+							},
+						},
+						Recv: &reflect.Arg{
+							Name: "c",
+							Type: &reflect.Type{
+								Name: "Pager",
+							},
+						},
+						Results: []reflect.Arg{
+							{
+								Type: &reflect.Type{
+									Name:    "Handler",
+									Package: "http",
+								},
+							},
+						},
+					},
+				},
+				Before: &reflect.Func{
+					Comments: []string{
+						"// Before is a magic method of Pager that is executed before",
+						"// actions of App too.",
+					},
+					File: "app.go",
+					Name: "Before",
+					Params: []reflect.Arg{
+						{
+							Name: "limit",
+							Type: &reflect.Type{
+								Name: "int",
+							},
+						},
+					},
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "Pager",
+							Star: true,
+						},
+					},
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "http",
+							},
+						},
+					},
+				},
+				Fields: []field{
+					{
+						Name: "W",
+						Type: "response",
+					},
+				},
+
+				Comments: []string{
+					"// Pager is a controller that is embedded into App through Base.",
+				},
+				File: "app.go",
+			},
+			"Controller": {
+				After: &reflect.Func{
+					Comments: []string{"// After is a magic method that is executed after every request."},
+					File:     "init.go",
+					Name:     "After",
+					Params: []reflect.Arg{
+						{
+							Name: "name",
+							Type: &reflect.Type{
+								Name: "string",
+							},
+						},
+					},
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "Controller",
+							Star: true,
+						},
+					},
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "h",
+							},
+						},
+					},
+				},
+				Before: &reflect.Func{
+					Comments: []string{"// Before is a magic method that is executed before every request."},
+					File:     "init.go",
+					Name:     "Before",
+					Params: []reflect.Arg{
+						{
+							Name: "uid",
+							Type: &reflect.Type{
+								Name: "string",
+							},
+						},
+					},
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "Controller",
+							Star: true,
+						},
+					},
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "h",
+							},
+						},
+					},
+				},
+				OnError: &reflect.Func{
+					Comments: []string{"// OnError is a magic method that is executed if an action returns an error."},
+					File:     "init.go",
+					Name:     "OnError",
+					Params: []reflect.Arg{
+						{
+							Name: "err",
+							Type: &reflect.Type{
+								Name: "error",
+							},
+						},
+					},
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "Controller",
+							Star: true,
+						},
+					},
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "h",
+							},
+						},
+					},
+				},
+				Recover: &reflect.Func{
+					Comments: []string{"// Recover is a magic method that is executed if an action panics."},
+					File:     "init.go",
+					Name:     "Recover",
+					Params: []reflect.Arg{
+						{
+							Name: "v",
+							Type: &reflect.Type{
+								Name: "interface{}",
+							},
+						},
+					},
+					Recv: &reflect.Arg{
+						Name: "c",
+						Type: &reflect.Type{
+							Name: "Controller",
+							Star: true,
+						},
+					},
+					Results: []reflect.Arg{
+						{
+							Type: &reflect.Type{
+								Name:    "Handler",
+								Package: "h",
+							},
+						},
+					},
+				},
+				Fields: []field{
+					{
+						Name: "R",
 						Type: "request",
 					},
 					{
@@ -300,6 +1327,14 @@ var ps = packages{
 						Name: "C",
 						Type: "controller",
 					},
+					{
+						Name: "Errors",
+						Type: "errors",
+					},
+					{
+						Name: "Ctx",
+						Type: "context",
+					},
 				},
 
 				Comments: []string{
@@ -311,10 +1346,20 @@ var ps = packages{
 					{
 						Import: "github.com/goaltools/goal/tools/generate/handlers/testdata/controllers/subpackage",
 						Name:   "Controller",
+						Field:  "Controller",
+						Star:   true,
+						Tag:    `@route:"/subpackage"`,
 					},
 					{
 						Import: "github.com/naoina/denco",
 						Name:   "Param",
+						Field:  "Param",
+						Star:   true,
+					},
+					{
+						Import: "testing",
+						Name:   "M",
+						Field:  "M",
 					},
 				},
 			},
@@ -0,0 +1,62 @@
+// Package bench compares the handlers generated with and without --pool flag.
+// Run "go test -bench . ./tools/generate/handlers/testdata/bench" to see the results.
+package bench
+
+//go:generate goal generate handlers --input ./controllers --output ./plain --package plain
+//go:generate goal generate handlers --input ./controllers --output ./pooled --package pooled --pool
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goaltools/goal/tools/generate/handlers/testdata/bench/plain"
+	"github.com/goaltools/goal/tools/generate/handlers/testdata/bench/pooled"
+)
+
+func TestHandlers(t *testing.T) {
+	for _, h := range []http.HandlerFunc{plain.App.Index, pooled.App.Index, pooled.App.Index} {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest("GET", "/?name=goal", nil))
+		if s := w.Body.String(); s != "Hello, goal" {
+			t.Errorf(`Incorrect response: "%s".`, s)
+		}
+	}
+}
+
+func TestPooled_Reset(t *testing.T) {
+	for _, name := range []string{"first", "second"} {
+		w := httptest.NewRecorder()
+		pooled.Visitor.Greet(w, httptest.NewRequest("GET", "/visitor?name="+name, nil))
+		if s := w.Body.String(); s != "Hello, "+name {
+			t.Errorf(`State of the previous request is expected to be reset, got "%s".`, s)
+		}
+	}
+}
+
+func TestHandlers_Recover(t *testing.T) {
+	for _, h := range []http.HandlerFunc{plain.Visitor.Fail, pooled.Visitor.Fail} {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest("GET", "/visitor/fail", nil))
+		if s := w.Body.String(); s != "Sorry, failed" {
+			t.Errorf(`Panic of the returned handler is expected to be recovered, got "%s".`, s)
+		}
+	}
+}
+
+func BenchmarkPlain(b *testing.B) {
+	benchmark(b, plain.App.Index)
+}
+
+func BenchmarkPooled(b *testing.B) {
+	benchmark(b, pooled.App.Index)
+}
+
+func benchmark(b *testing.B, h http.HandlerFunc) {
+	r := httptest.NewRequest("GET", "/?name=goal", nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h(httptest.NewRecorder(), r)
+	}
+}
@@ -0,0 +1,93 @@
+// Package controllers is used for benchmarking of the handlers
+// generated with and without --pool flag.
+package controllers
+
+import (
+	"context"
+	"net/http"
+)
+
+// Controller is a parent controller with all kinds of bound fields.
+type Controller struct {
+	W   http.ResponseWriter `bind:"response"`
+	R   *http.Request       `bind:"request"`
+	C   string              `bind:"controller"`
+	A   string              `bind:"action"`
+	Ctx context.Context     `bind:"context"`
+}
+
+// Before is a magic method that is executed before every action.
+// It parses the form the parameters of the actions are read from.
+func (c *Controller) Before() http.Handler {
+	c.R.ParseForm()
+	return nil
+}
+
+// After is a magic method that is executed after every action.
+func (c *Controller) After() http.Handler {
+	return nil
+}
+
+// App is a controller with a buffer that is reused
+// by the pooled handlers.
+type App struct {
+	*Controller
+
+	buf []byte
+}
+
+// Index greets the user.
+//@get /
+func (c *App) Index(name string) http.Handler {
+	c.buf = append(c.buf, "Hello, "...)
+	c.buf = append(c.buf, name...)
+	return text(c.buf)
+}
+
+// Reset is a magic method that prepares App for reuse.
+func (c *App) Reset() {
+	c.buf = c.buf[:0]
+}
+
+// Visitor is a controller without magic Reset method,
+// so it is zeroed by the pooled handlers before it is reused.
+type Visitor struct {
+	*Controller
+
+	name string
+}
+
+// Greet greets the visitor whose name is not known yet.
+//@get /visitor
+func (c *Visitor) Greet(name string) http.Handler {
+	if c.name == "" {
+		c.name = name
+	}
+	return text("Hello, " + c.name)
+}
+
+// Fail returns a handler that panics while writing the response.
+//@get /visitor/fail
+func (c *Visitor) Fail() http.Handler {
+	return failure{}
+}
+
+// Recover is a magic method that is executed if the action
+// or the handler it returns panics.
+func (c *Visitor) Recover(v interface{}) http.Handler {
+	return text("Sorry, " + v.(string))
+}
+
+// failure is a handler that panics.
+type failure struct{}
+
+func (failure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	panic("failed")
+}
+
+// text is a handler that writes itself to the response.
+type text []byte
+
+func (t text) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Write(t)
+}
@@ -0,0 +1,252 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package plain is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package plain
+
+import (
+	"net/http"
+	"net/url"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// App is an insance of tApp that is automatically generated from App controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// App is a controller with a buffer that is reused
+// by the pooled handlers.
+var App tApp
+
+// context stores names of all controllers and packages of the app.
+var context = url.Values{}
+
+// internalServerError is a handler that is used if an action returns
+// an error but there is no magic OnError method to handle it.
+var internalServerError = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+})
+
+// badRequest is a handler that is used if the form of a request
+// whose action parameters must be validated cannot be parsed.
+var badRequest = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+})
+
+// serve writes the response using the handler and returns
+// the value recovered if the handler panics.
+func serve(h http.Handler, w http.ResponseWriter, r *http.Request) (v interface{}) {
+	defer func() {
+		v = recover()
+	}()
+	h.ServeHTTP(w, r)
+	return
+}
+
+// skipped checks whether the controller is among the ones
+// whose magic methods are omitted by an action or whether
+// the handler is among the shadowed ones.
+func skipped(skip []string, ctr string) bool {
+	for i := range skip {
+		if skip[i] == ctr {
+			return true
+		}
+	}
+	return false
+}
+
+// shadow returns a new list of the shadowed handlers
+// that consists of the existing and the extra ones.
+func shadow(shadowed []string, extra ...string) []string {
+	return append(append([]string{}, shadowed...), extra...)
+}
+
+// tApp is a type with handler methods of App controller.
+type tApp struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).App controller,
+// initializes its parents; then returns the controller.
+func (t tApp) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.App {
+	c := &contr.App{}
+	c.Controller = Controller.New(w, r, ctr, act)
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).App controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tApp) SetErrors(c *contr.App, errs validation.Errors) (ok bool) {
+	if Controller.SetErrors(c.Controller, errs) {
+		ok = true
+	}
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tApp) Before(c *contr.App, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.App") {
+		return nil
+	}
+
+	// Execute magic Before actions of embedded controllers.
+	if h := Controller.Before(c.Controller, w, r, skip...); h != nil {
+		return h
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tApp) After(c *contr.App, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.App") {
+		return nil
+	}
+
+	// Execute magic After methods of embedded controllers.
+
+	if h = Controller.After(c.Controller, w, r, skip...); h != nil {
+		return h
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tApp) OnError(c *contr.App, r *http.Request, err error) http.Handler {
+
+	if h := Controller.OnError(c.Controller, r, err); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tApp) Recover(c *contr.App, r *http.Request, v interface{}) http.Handler {
+
+	if h := Controller.Recover(c.Controller, r, v); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tApp) Finally(c *contr.App, r *http.Request) {
+
+	Controller.Finally(c.Controller, r)
+
+}
+
+// Index is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Index action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go
+// in appropriate order.
+//
+// Index greets the user.
+//@get /
+func (t tApp) Index(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := App.New(w, r, "App", "Index")
+
+	defer App.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = App.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = App.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer App.After(c, w, r)
+
+	if res := App.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Index(
+		strconv.String(r.Form, "name"),
+	); res != nil {
+		h = res
+		return
+	}
+}
+
+// Init initializes controllers of "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers",
+// its parents, and returns a list of routes along
+// with handler functions associated with them.
+// Routes of the controllers that are embedded into other ones
+// are returned by the latter, wrapped with their middleware.
+// Routes of the controllers that are embedded into several ones
+// are returned once, without middleware of the latter.
+// Routes of the shadowed handlers, e.g. "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.App.Index",
+// are omitted.
+func Init(shadowed ...string) (routes []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+
+	routes = append(routes, initApp(shadowed)...)
+
+	routes = append(routes, initController(shadowed)...)
+
+	routes = append(routes, initVisitor(shadowed)...)
+
+	return
+}
+
+func initApp(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["App"] = []string{"Index"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.App.Index") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/",
+				Label:   "",
+				Handler: App.Index,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
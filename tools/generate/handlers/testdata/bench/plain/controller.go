@@ -0,0 +1,119 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package plain is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package plain
+
+import (
+	"net/http"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Controller is an insance of tController that is automatically generated from Controller controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// Controller is a parent controller with all kinds of bound fields.
+var Controller tController
+
+// tController is a type with handler methods of Controller controller.
+type tController struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Controller controller,
+// then returns it.
+func (t tController) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Controller {
+	c := &contr.Controller{
+		W: w,
+
+		R: r,
+
+		C: ctr,
+
+		A: act,
+
+		Ctx: r.Context(),
+	}
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Controller controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tController) SetErrors(c *contr.Controller, errs validation.Errors) (ok bool) {
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tController) Before(c *contr.Controller, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Controller") {
+		return nil
+	}
+
+	// Call magic Before action of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Before.
+	if h := c.Before(); h != nil {
+		return h
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tController) After(c *contr.Controller, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Controller") {
+		return nil
+	}
+
+	// Call magic After method of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Controller.
+	defer func() {
+		if h == nil {
+			h = c.After()
+		}
+	}()
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tController) OnError(c *contr.Controller, r *http.Request, err error) http.Handler {
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tController) Recover(c *contr.Controller, r *http.Request, v interface{}) http.Handler {
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tController) Finally(c *contr.Controller, r *http.Request) {
+
+}
+
+func initController(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
@@ -0,0 +1,240 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package plain is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package plain
+
+import (
+	"net/http"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Visitor is an insance of tVisitor that is automatically generated from Visitor controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// Visitor is a controller without magic Reset method,
+// so it is zeroed by the pooled handlers before it is reused.
+var Visitor tVisitor
+
+// tVisitor is a type with handler methods of Visitor controller.
+type tVisitor struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Visitor controller,
+// initializes its parents; then returns the controller.
+func (t tVisitor) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Visitor {
+	c := &contr.Visitor{}
+	c.Controller = Controller.New(w, r, ctr, act)
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Visitor controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tVisitor) SetErrors(c *contr.Visitor, errs validation.Errors) (ok bool) {
+	if Controller.SetErrors(c.Controller, errs) {
+		ok = true
+	}
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tVisitor) Before(c *contr.Visitor, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Visitor") {
+		return nil
+	}
+
+	// Execute magic Before actions of embedded controllers.
+	if h := Controller.Before(c.Controller, w, r, skip...); h != nil {
+		return h
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tVisitor) After(c *contr.Visitor, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Visitor") {
+		return nil
+	}
+
+	// Execute magic After methods of embedded controllers.
+
+	if h = Controller.After(c.Controller, w, r, skip...); h != nil {
+		return h
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tVisitor) OnError(c *contr.Visitor, r *http.Request, err error) http.Handler {
+
+	if h := Controller.OnError(c.Controller, r, err); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tVisitor) Recover(c *contr.Visitor, r *http.Request, v interface{}) http.Handler {
+
+	// Call magic Recover method of (github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers).Visitor.
+	return c.Recover(v)
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tVisitor) Finally(c *contr.Visitor, r *http.Request) {
+
+	Controller.Finally(c.Controller, r)
+
+}
+
+// Greet is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Greet action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go
+// in appropriate order.
+//
+// Greet greets the visitor whose name is not known yet.
+//@get /visitor
+func (t tVisitor) Greet(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Visitor.New(w, r, "Visitor", "Greet")
+
+	defer Visitor.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Visitor.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Visitor.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Visitor.After(c, w, r)
+
+	if res := Visitor.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Greet(
+		strconv.String(r.Form, "name"),
+	); res != nil {
+		h = res
+		return
+	}
+}
+
+// Fail is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Fail action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers/app.go
+// in appropriate order.
+//
+// Fail returns a handler that panics while writing the response.
+//@get /visitor/fail
+func (t tVisitor) Fail(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Visitor.New(w, r, "Visitor", "Fail")
+
+	defer Visitor.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Visitor.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Visitor.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Visitor.After(c, w, r)
+
+	if res := Visitor.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Fail(); res != nil {
+		h = res
+		return
+	}
+}
+
+func initVisitor(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["Visitor"] = []string{"Greet", "Fail"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Visitor.Greet") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/visitor",
+				Label:   "",
+				Handler: Visitor.Greet,
+			},
+		}...)
+	}
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/bench/controllers.Visitor.Fail") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/visitor/fail",
+				Label:   "",
+				Handler: Visitor.Fail,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
@@ -1,21 +1,50 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"math/big"
 	"net/http"
 	"testing"
+	"time"
 )
 
 // App is a sample controller.
+//@use Logged
 type App struct {
 	*Controller
 	*NotController
 	*NotController1
+	Base
 
 	// Fields with incorrect types.
 	a http.ResponseWriter `bind:"request"`
 	b string              `bind:"response"`
 	c int                 `bind:"action"`
 	d int                 `bind:"controller"`
+	e []string            `bind:"errors"`
+}
+
+// Base is not a controller but Pager that is embedded into it by value
+// is promoted to App.
+type Base struct {
+	Pager
+}
+
+// Pager is a controller that is embedded into App through Base.
+type Pager struct {
+	W http.ResponseWriter `bind:"response"`
+}
+
+// Before is a magic method of Pager that is executed before
+// actions of App too.
+func (c *Pager) Before(limit int) http.Handler {
+	return nil
+}
+
+// Page is an action of Pager that is promoted to App.
+func (c Pager) Page(n int) http.Handler {
+	return nil
 }
 
 // NotController is not a controller as it doesn't have methods.
@@ -38,6 +67,125 @@ func (c App) HelloWorld(page int) (http.Handler, bool, error) {
 	return nil, false, nil
 }
 
+// Register is an action with parameters of local structure types
+// that are bound from the form.
+func (c App) Register(u UserForm, addr *Address) http.Handler {
+	return nil
+}
+
+// Create is an action that expects a JSON or XML request body.
+//@post /users
+//@body input
+func (c App) Create(input *UserForm, page int) http.Handler {
+	return nil
+}
+
+// Show is an action with parameters of named types.
+func (c App) Show(id UserID, slug Slug, code Code, n big.Int) http.Handler {
+	return nil
+}
+
+// Report is an action with time parameters.
+//@get
+//@layout to 02.01.2006
+func (c App) Report(from, to time.Time, every time.Duration, p Period) http.Handler {
+	return nil
+}
+
+// Search is an action with validated parameters.
+//@get
+//@validate q required
+//@validate page min=1 max=100
+func (c App) Search(q string, page int) http.Handler {
+	return nil
+}
+
+// Export is an action that expects the request's context.
+func (c App) Export(ctx context.Context, format string) (http.Handler, error) {
+	return nil, ctx.Err()
+}
+
+// Profile is an action whose parameters are read from different sources.
+//@post
+//@param token header:X-Auth-Token
+//@param sid cookie:session
+//@param page query
+//@param id path
+//@validate token required
+func (c App) Profile(token, sid string, page, id int) http.Handler {
+	return nil
+}
+
+// Period is a structure with time fields.
+type Period struct {
+	Since time.Time   `form:"since" layout:"2006-01"`
+	Dates []time.Time `form:"dates"`
+}
+
+// UserID is a named type with a builtin underlying type.
+type UserID int64
+
+// Slug implements encoding.TextUnmarshaler.
+type Slug string
+
+// UnmarshalText validates the slug.
+func (s *Slug) UnmarshalText(t []byte) error {
+	if len(t) == 0 {
+		return errors.New("empty slug")
+	}
+	*s = Slug(t)
+	return nil
+}
+
+// Code has a FromString method.
+type Code struct {
+	Value string
+}
+
+// FromString parses the code.
+func (c *Code) FromString(s string) error {
+	c.Value = s
+	return nil
+}
+
+// UnsupportedForm is not an action as a field of the structure
+// is not of builtin type.
+func (c App) UnsupportedForm(f TestForm) http.Handler {
+	return nil
+}
+
+// UserForm is a sample structure that is bound from the form.
+type UserForm struct {
+	Meta
+
+	ID      UserID   `form:"id"`
+	Name    string   `form:"name" validate:"required max=64"`
+	Age     int      `form:"age"`
+	Tags    []string `form:"tags"`
+	Address Address  `form:"address"`
+	Home    *Address
+	Ignored string `form:"-"`
+	private string
+}
+
+// Meta is embedded into UserForm, its fields are bound
+// without a prefix.
+type Meta struct {
+	Token string `form:"token"`
+}
+
+// Address is a nested structure, its fields are bound
+// with "address." prefix.
+type Address struct {
+	City string `form:"city,omitempty"`
+	Zip  uint   `validate:"max=99999"`
+}
+
+// TestForm has an unsupported field.
+type TestForm struct {
+	T *testing.T
+}
+
 // Initially is a magic method that is executed before every request.
 func (c *Controller) Initially(w http.ResponseWriter, r *http.Request, a []string) bool {
 	return false
@@ -56,6 +204,21 @@ func (c Controller) UnsupportedAction(t *testing.T) http.Handler {
 	return nil
 }
 
+// Finally is a magic method of App that is executed at the very end of every request.
+// It shadows Finally method of the embedded Controller.
+func (c *App) Finally() {
+}
+
+// Reset is a magic method of App that is executed before
+// the controller is reused by the handlers generated with --pool.
+func (c *App) Reset() {
+}
+
+// Logged is a middleware that wraps handlers of App's actions.
+func Logged(h http.Handler) http.Handler {
+	return h
+}
+
 // Smth is not an action as it returns nothing.
 func (c App) Smth() {
 }
@@ -0,0 +1,12 @@
+package controllers
+
+// cleanup is not a controller though its Finally method
+// has a signature of the magic one.
+type cleanup struct {
+	done bool
+}
+
+// Finally is not a magic method as cleanup has no actions.
+func (c *cleanup) Finally() {
+	c.done = true
+}
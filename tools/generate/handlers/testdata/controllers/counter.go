@@ -0,0 +1,12 @@
+package controllers
+
+// counter is not a controller though its Reset method
+// has a signature of the magic one.
+type counter struct {
+	n int
+}
+
+// Reset is not a magic method as counter has no actions.
+func (c *counter) Reset() {
+	c.n = 0
+}
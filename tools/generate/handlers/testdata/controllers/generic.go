@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+)
+
+// Resource is a generic controller, its actions and magic methods
+// are instantiated for every concrete structure that embeds it.
+type Resource[T any] struct {
+	W http.ResponseWriter `bind:"response"`
+}
+
+// Before is a magic method of Resource.
+func (c *Resource[T]) Before() http.Handler {
+	return nil
+}
+
+// List is an action of Resource.
+//@get
+func (c *Resource[T]) List(page int) http.Handler {
+	return nil
+}
+
+// Create is an action whose parameter is of the type parameter's type.
+//@post
+//@body item
+func (c *Resource[T]) Create(item T) http.Handler {
+	return nil
+}
+
+// Users is not a controller as it doesn't have methods,
+// but Resource is instantiated for it.
+type Users struct {
+	*Resource[UserForm] `@route:"/users"`
+}
@@ -1,10 +1,12 @@
 package controllers
 
 import (
+	"context"
 	h "net/http"
 	"testing"
 
 	"github.com/goaltools/goal/tools/generate/handlers/testdata/controllers/subpackage"
+	"github.com/goaltools/goal/validation"
 
 	"github.com/naoina/denco"
 )
@@ -23,6 +25,9 @@ type Controller struct {
 	A string           `bind:"action"`
 	C string           `bind:"controller"`
 
+	Errors validation.Errors `bind:"errors"`
+	Ctx    context.Context   `bind:"context"`
+
 	// r is not exported and thus must be ignored.
 	r *h.Request `bind:"request"`
 }
@@ -32,6 +37,16 @@ func (c *Controller) Before(uid string) h.Handler {
 	return nil
 }
 
+// OnError is a magic method that is executed if an action returns an error.
+func (c *Controller) OnError(err error) h.Handler {
+	return nil
+}
+
+// Recover is a magic method that is executed if an action panics.
+func (c *Controller) Recover(v interface{}) h.Handler {
+	return nil
+}
+
 // index is not an action as this method is not public.
 func (c Controller) index(page int) h.Handler {
 	return nil
@@ -42,6 +57,21 @@ func (c *App) Index(page int) h.Handler {
 	return nil
 }
 
+// Secret is an action that is wrapped with a middleware.
+//@get
+//@use subpackage.Auth
+func (c *App) Secret() h.Handler {
+	return nil
+}
+
+// Health is an action that omits magic methods of the parent controllers.
+//@get
+//@skip subpackage.Controller
+//@skip After
+func (c *App) Health() h.Handler {
+	return nil
+}
+
 // NotAction is not an action as this method doesn't return
 // action.Result as its first argument.
 func (c Controller) NotAction(page int) (bool, h.Handler) {
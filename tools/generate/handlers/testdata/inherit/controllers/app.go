@@ -0,0 +1,112 @@
+// Package controllers is used for testing of the routes of parent
+// controllers declared in the same and in another package.
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib"
+)
+
+// App is a controller whose middleware wrap handlers
+// of its own actions and of the actions of its parents.
+// Actions of the parents it shadows are not served.
+//@use Tagged
+type App struct {
+	*Local
+	*lib.Remote `@route:"/lib"`
+	*Base
+}
+
+// Index is an action of App.
+//@get /app
+func (c *App) Index() http.Handler {
+	return text("app")
+}
+
+// Local is a parent controller declared in the same package.
+type Local struct {
+}
+
+// Ping is an action of Local.
+//@get /local
+func (c *Local) Ping() http.Handler {
+	return text("local")
+}
+
+// Index is an action of Local that is shadowed by App.Index.
+//@get /local/index
+func (c *Local) Index() http.Handler {
+	return text("local index")
+}
+
+// Base is not a controller, but actions of its parents
+// are promoted to the controllers that embed it.
+type Base struct {
+	Pager
+}
+
+// Pager is a parent controller that is promoted to App through Base.
+type Pager struct {
+}
+
+// List is an action of Pager.
+//@get /list
+func (c *Pager) List() http.Handler {
+	return text("list")
+}
+
+// Ping is an action of Pager that is shadowed by Local.Ping,
+// as the latter is embedded into App at a shallower depth.
+//@get /pager/ping
+func (c *Pager) Ping() http.Handler {
+	return text("pager")
+}
+
+// First is a controller that shares a parent with Second.
+type First struct {
+	*Shared
+}
+
+// Index is an action of First.
+//@get /first
+func (c *First) Index() http.Handler {
+	return text("first")
+}
+
+// Second is a controller that shares a parent with First.
+type Second struct {
+	*Shared
+}
+
+// Index is an action of Second.
+//@get /second
+func (c *Second) Index() http.Handler {
+	return text("second")
+}
+
+// Shared is a parent controller that is embedded into several controllers,
+// so its routes are registered once rather than by every of them.
+type Shared struct {
+}
+
+// Ping is an action of Shared.
+//@get /shared
+func (c *Shared) Ping() http.Handler {
+	return text("shared")
+}
+
+// Tagged is a middleware that marks responses of the handlers it wraps.
+func Tagged(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Tagged", "true")
+		h.ServeHTTP(w, r)
+	})
+}
+
+// text is a handler that writes itself to the response.
+type text string
+
+func (t text) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(t))
+}
@@ -0,0 +1,27 @@
+// Package lib is used for testing of the routes of parent
+// controllers declared in another package.
+package lib
+
+import (
+	"net/http"
+)
+
+// Remote is a parent controller declared in another package.
+type Remote struct {
+}
+
+// Pong is an action of Remote.
+//@get /remote
+func (c *Remote) Pong() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("remote"))
+	})
+}
+
+// Index is an action of Remote that is shadowed by App.Index.
+//@get /remote/index
+func (c *Remote) Index() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("remote index"))
+	})
+}
@@ -0,0 +1,313 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package handlers is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	c1 "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/handlers/github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// App is an insance of tApp that is automatically generated from App controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// App is a controller whose middleware wrap handlers
+// of its own actions and of the actions of its parents.
+// Actions of the parents it shadows are not served.
+// @use Tagged
+var App tApp
+
+// context stores names of all controllers and packages of the app.
+var context = url.Values{}
+
+// internalServerError is a handler that is used if an action returns
+// an error but there is no magic OnError method to handle it.
+var internalServerError = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+})
+
+// badRequest is a handler that is used if the form of a request
+// whose action parameters must be validated cannot be parsed.
+var badRequest = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+})
+
+// serve writes the response using the handler and returns
+// the value recovered if the handler panics.
+func serve(h http.Handler, w http.ResponseWriter, r *http.Request) (v interface{}) {
+	defer func() {
+		v = recover()
+	}()
+	h.ServeHTTP(w, r)
+	return
+}
+
+// skipped checks whether the controller is among the ones
+// whose magic methods are omitted by an action or whether
+// the handler is among the shadowed ones.
+func skipped(skip []string, ctr string) bool {
+	for i := range skip {
+		if skip[i] == ctr {
+			return true
+		}
+	}
+	return false
+}
+
+// shadow returns a new list of the shadowed handlers
+// that consists of the existing and the extra ones.
+func shadow(shadowed []string, extra ...string) []string {
+	return append(append([]string{}, shadowed...), extra...)
+}
+
+// tApp is a type with handler methods of App controller.
+type tApp struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).App controller,
+// initializes its parents; then returns the controller.
+func (t tApp) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.App {
+	c := &contr.App{}
+	c.Base = &contr.Base{}
+	c.Local = Local.New(w, r, ctr, act)
+	c.Remote = c1.Remote.New(w, r, ctr, act)
+	c.Base.Pager = *Pager.New(w, r, ctr, act)
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).App controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tApp) SetErrors(c *contr.App, errs validation.Errors) (ok bool) {
+	if Local.SetErrors(c.Local, errs) {
+		ok = true
+	}
+	if c1.Remote.SetErrors(c.Remote, errs) {
+		ok = true
+	}
+	if Pager.SetErrors(&c.Base.Pager, errs) {
+		ok = true
+	}
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tApp) Before(c *contr.App, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.App") {
+		return nil
+	}
+
+	// Execute magic Before actions of embedded controllers.
+	if h := Local.Before(c.Local, w, r, skip...); h != nil {
+		return h
+	}
+
+	if h := c1.Remote.Before(c.Remote, w, r, skip...); h != nil {
+		return h
+	}
+
+	if h := Pager.Before(&c.Base.Pager, w, r, skip...); h != nil {
+		return h
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tApp) After(c *contr.App, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.App") {
+		return nil
+	}
+
+	// Execute magic After methods of embedded controllers.
+
+	if h = Local.After(c.Local, w, r, skip...); h != nil {
+		return h
+	}
+
+	if h = c1.Remote.After(c.Remote, w, r, skip...); h != nil {
+		return h
+	}
+
+	if h = Pager.After(&c.Base.Pager, w, r, skip...); h != nil {
+		return h
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tApp) OnError(c *contr.App, r *http.Request, err error) http.Handler {
+
+	if h := Local.OnError(c.Local, r, err); h != nil {
+		return h
+	}
+
+	if h := c1.Remote.OnError(c.Remote, r, err); h != nil {
+		return h
+	}
+
+	if h := Pager.OnError(&c.Base.Pager, r, err); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tApp) Recover(c *contr.App, r *http.Request, v interface{}) http.Handler {
+
+	if h := Local.Recover(c.Local, r, v); h != nil {
+		return h
+	}
+
+	if h := c1.Remote.Recover(c.Remote, r, v); h != nil {
+		return h
+	}
+
+	if h := Pager.Recover(&c.Base.Pager, r, v); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tApp) Finally(c *contr.App, r *http.Request) {
+
+	Local.Finally(c.Local, r)
+
+	c1.Remote.Finally(c.Remote, r)
+
+	Pager.Finally(&c.Base.Pager, r)
+
+}
+
+// Index is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Index action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// Index is an action of App.
+//@get /app
+func (t tApp) Index(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := App.New(w, r, "App", "Index")
+
+	defer App.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = App.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = App.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer App.After(c, w, r)
+
+	if res := App.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Index(); res != nil {
+		h = res
+		return
+	}
+}
+
+// Init initializes controllers of "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers",
+// its parents, and returns a list of routes along
+// with handler functions associated with them.
+// Routes of the controllers that are embedded into other ones
+// are returned by the latter, wrapped with their middleware.
+// Routes of the controllers that are embedded into several ones
+// are returned once, without middleware of the latter.
+// Routes of the shadowed handlers, e.g. "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.App.Index",
+// are omitted.
+func Init(shadowed ...string) (routes []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+
+	routes = append(routes, initApp(shadowed)...)
+
+	routes = append(routes, initFirst(shadowed)...)
+
+	routes = append(routes, initSecond(shadowed)...)
+
+	routes = append(routes, initShared(shadowed)...)
+
+	return
+}
+
+func initApp(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	rs = append(rs, initLocal(shadow(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Local.Index"))...)
+
+	rs = append(rs, c1.Init(shadow(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.Remote.Index")...)...)
+
+	rs = append(rs, initPager(shadow(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Pager.Ping"))...)
+
+	context["App"] = []string{"Index"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.App.Index") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/app",
+				Label:   "",
+				Handler: App.Index,
+			},
+		}...)
+	}
+
+	// Wrap handlers of the controller and its parents
+	// with middleware of the controller.
+	for i := range rs {
+		rs[i].Handler = contr.Tagged(rs[i].Handler).ServeHTTP
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
@@ -0,0 +1,278 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package handlers is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Remote is an insance of tRemote that is automatically generated from Remote controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib/lib.go",
+// and contains methods to be used as handler functions.
+//
+// Remote is a parent controller declared in another package.
+var Remote tRemote
+
+// context stores names of all controllers and packages of the app.
+var context = url.Values{}
+
+// internalServerError is a handler that is used if an action returns
+// an error but there is no magic OnError method to handle it.
+var internalServerError = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+})
+
+// badRequest is a handler that is used if the form of a request
+// whose action parameters must be validated cannot be parsed.
+var badRequest = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+})
+
+// serve writes the response using the handler and returns
+// the value recovered if the handler panics.
+func serve(h http.Handler, w http.ResponseWriter, r *http.Request) (v interface{}) {
+	defer func() {
+		v = recover()
+	}()
+	h.ServeHTTP(w, r)
+	return
+}
+
+// skipped checks whether the controller is among the ones
+// whose magic methods are omitted by an action or whether
+// the handler is among the shadowed ones.
+func skipped(skip []string, ctr string) bool {
+	for i := range skip {
+		if skip[i] == ctr {
+			return true
+		}
+	}
+	return false
+}
+
+// shadow returns a new list of the shadowed handlers
+// that consists of the existing and the extra ones.
+func shadow(shadowed []string, extra ...string) []string {
+	return append(append([]string{}, shadowed...), extra...)
+}
+
+// tRemote is a type with handler methods of Remote controller.
+type tRemote struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib).Remote controller,
+// then returns it.
+func (t tRemote) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Remote {
+	c := &contr.Remote{}
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib).Remote controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tRemote) SetErrors(c *contr.Remote, errs validation.Errors) (ok bool) {
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tRemote) Before(c *contr.Remote, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.Remote") {
+		return nil
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tRemote) After(c *contr.Remote, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.Remote") {
+		return nil
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tRemote) OnError(c *contr.Remote, r *http.Request, err error) http.Handler {
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tRemote) Recover(c *contr.Remote, r *http.Request, v interface{}) http.Handler {
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tRemote) Finally(c *contr.Remote, r *http.Request) {
+
+}
+
+// Pong is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Pong action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib/lib.go
+// in appropriate order.
+//
+// Pong is an action of Remote.
+//@get /remote
+func (t tRemote) Pong(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Remote.New(w, r, "Remote", "Pong")
+
+	defer Remote.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Remote.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Remote.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Remote.After(c, w, r)
+
+	if res := Remote.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Pong(); res != nil {
+		h = res
+		return
+	}
+}
+
+// Index is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Index action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib/lib.go
+// in appropriate order.
+//
+// Index is an action of Remote that is shadowed by App.Index.
+//@get /remote/index
+func (t tRemote) Index(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Remote.New(w, r, "Remote", "Index")
+
+	defer Remote.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Remote.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Remote.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Remote.After(c, w, r)
+
+	if res := Remote.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Index(); res != nil {
+		h = res
+		return
+	}
+}
+
+// Init initializes controllers of "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib",
+// its parents, and returns a list of routes along
+// with handler functions associated with them.
+// Routes of the controllers that are embedded into other ones
+// are returned by the latter, wrapped with their middleware.
+// Routes of the controllers that are embedded into several ones
+// are returned once, without middleware of the latter.
+// Routes of the shadowed handlers, e.g. "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.App.Index",
+// are omitted.
+func Init(shadowed ...string) (routes []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+
+	routes = append(routes, initRemote(shadowed)...)
+
+	return
+}
+
+func initRemote(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["Remote"] = []string{"Pong", "Index"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.Remote.Pong") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/lib/remote",
+				Label:   "",
+				Handler: Remote.Pong,
+			},
+		}...)
+	}
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/lib.Remote.Index") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/lib/remote/index",
+				Label:   "",
+				Handler: Remote.Index,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
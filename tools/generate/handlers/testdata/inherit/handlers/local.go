@@ -0,0 +1,215 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package handlers is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package handlers
+
+import (
+	"net/http"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Local is an insance of tLocal that is automatically generated from Local controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// Local is a parent controller declared in the same package.
+var Local tLocal
+
+// tLocal is a type with handler methods of Local controller.
+type tLocal struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Local controller,
+// then returns it.
+func (t tLocal) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Local {
+	c := &contr.Local{}
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Local controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tLocal) SetErrors(c *contr.Local, errs validation.Errors) (ok bool) {
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tLocal) Before(c *contr.Local, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Local") {
+		return nil
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tLocal) After(c *contr.Local, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Local") {
+		return nil
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tLocal) OnError(c *contr.Local, r *http.Request, err error) http.Handler {
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tLocal) Recover(c *contr.Local, r *http.Request, v interface{}) http.Handler {
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tLocal) Finally(c *contr.Local, r *http.Request) {
+
+}
+
+// Ping is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Ping action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// Ping is an action of Local.
+//@get /local
+func (t tLocal) Ping(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Local.New(w, r, "Local", "Ping")
+
+	defer Local.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Local.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Local.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Local.After(c, w, r)
+
+	if res := Local.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Ping(); res != nil {
+		h = res
+		return
+	}
+}
+
+// Index is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Index action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// Index is an action of Local that is shadowed by App.Index.
+//@get /local/index
+func (t tLocal) Index(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Local.New(w, r, "Local", "Index")
+
+	defer Local.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Local.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Local.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Local.After(c, w, r)
+
+	if res := Local.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Index(); res != nil {
+		h = res
+		return
+	}
+}
+
+func initLocal(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["Local"] = []string{"Ping", "Index"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Local.Ping") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/local",
+				Label:   "",
+				Handler: Local.Ping,
+			},
+		}...)
+	}
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Local.Index") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/local/index",
+				Label:   "",
+				Handler: Local.Index,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
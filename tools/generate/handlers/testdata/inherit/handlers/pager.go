@@ -0,0 +1,216 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package handlers is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package handlers
+
+import (
+	"net/http"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Pager is an insance of tPager that is automatically generated from Pager controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// Pager is a parent controller that is promoted to App through Base.
+var Pager tPager
+
+// tPager is a type with handler methods of Pager controller.
+type tPager struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Pager controller,
+// then returns it.
+func (t tPager) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Pager {
+	c := &contr.Pager{}
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Pager controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tPager) SetErrors(c *contr.Pager, errs validation.Errors) (ok bool) {
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tPager) Before(c *contr.Pager, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Pager") {
+		return nil
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tPager) After(c *contr.Pager, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Pager") {
+		return nil
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tPager) OnError(c *contr.Pager, r *http.Request, err error) http.Handler {
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tPager) Recover(c *contr.Pager, r *http.Request, v interface{}) http.Handler {
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tPager) Finally(c *contr.Pager, r *http.Request) {
+
+}
+
+// List is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and List action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// List is an action of Pager.
+//@get /list
+func (t tPager) List(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Pager.New(w, r, "Pager", "List")
+
+	defer Pager.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Pager.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Pager.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Pager.After(c, w, r)
+
+	if res := Pager.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.List(); res != nil {
+		h = res
+		return
+	}
+}
+
+// Ping is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Ping action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// Ping is an action of Pager that is shadowed by Local.Ping,
+// as the latter is embedded into App at a shallower depth.
+//@get /pager/ping
+func (t tPager) Ping(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Pager.New(w, r, "Pager", "Ping")
+
+	defer Pager.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Pager.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Pager.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Pager.After(c, w, r)
+
+	if res := Pager.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Ping(); res != nil {
+		h = res
+		return
+	}
+}
+
+func initPager(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["Pager"] = []string{"List", "Ping"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Pager.List") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/list",
+				Label:   "",
+				Handler: Pager.List,
+			},
+		}...)
+	}
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Pager.Ping") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/pager/ping",
+				Label:   "",
+				Handler: Pager.Ping,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
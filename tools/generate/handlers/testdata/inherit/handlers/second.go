@@ -0,0 +1,182 @@
+// Code generated by goal toolkit. DO NOT EDIT.
+
+// Package handlers is generated automatically by goal toolkit.
+// Please, do not edit it manually.
+package handlers
+
+import (
+	"net/http"
+
+	contr "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers"
+
+	"github.com/goaltools/goal/strconv"
+	"github.com/goaltools/goal/validation"
+)
+
+// Second is an insance of tSecond that is automatically generated from Second controller
+// being found at "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go",
+// and contains methods to be used as handler functions.
+//
+// Second is a controller that shares a parent with First.
+var Second tSecond
+
+// tSecond is a type with handler methods of Second controller.
+type tSecond struct {
+}
+
+// New allocates (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Second controller,
+// initializes its parents; then returns the controller.
+func (t tSecond) New(w http.ResponseWriter, r *http.Request, ctr, act string) *contr.Second {
+	c := &contr.Second{}
+	c.Shared = Shared.New(w, r, ctr, act)
+	return c
+}
+
+// SetErrors binds validation errors to the fields of (github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers).Second controller
+// and its parents that are tagged with `bind:"errors"`. It returns true
+// if at least one such field exists.
+func (t tSecond) SetErrors(c *contr.Second, errs validation.Errors) (ok bool) {
+	if Shared.SetErrors(c.Shared, errs) {
+		ok = true
+	}
+	return
+}
+
+// Before is a method that is started by every handler function at the very beginning
+// of their execution phase unless the controller is among the skipped ones.
+func (t tSecond) Before(c *contr.Second, w http.ResponseWriter, r *http.Request, skip ...string) http.Handler {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Second") {
+		return nil
+	}
+
+	// Execute magic Before actions of embedded controllers.
+	if h := Shared.Before(c.Shared, w, r, skip...); h != nil {
+		return h
+	}
+
+	return nil
+}
+
+// After is a method that is started by every handler function at the very end
+// of their execution phase unless the controller is among the skipped ones.
+func (t tSecond) After(c *contr.Second, w http.ResponseWriter, r *http.Request, skip ...string) (h http.Handler) {
+	if skipped(skip, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Second") {
+		return nil
+	}
+
+	// Execute magic After methods of embedded controllers.
+
+	if h = Shared.After(c.Shared, w, r, skip...); h != nil {
+		return h
+	}
+
+	return
+}
+
+// OnError is a method that is started by handler functions if their actions
+// return a non-nil error. The error is passed to the magic OnError method of
+// the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tSecond) OnError(c *contr.Second, r *http.Request, err error) http.Handler {
+
+	if h := Shared.OnError(c.Shared, r, err); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Recover is a method that is started by handler functions if their actions
+// or magic methods panic. The recovered value is passed to the magic Recover method
+// of the controller or, if there is none, of its nearest parent that has it.
+// If none of them has the method, nil is returned.
+func (t tSecond) Recover(c *contr.Second, r *http.Request, v interface{}) http.Handler {
+
+	if h := Shared.Recover(c.Shared, r, v); h != nil {
+		return h
+	}
+
+	return nil
+
+}
+
+// Finally is a method that is started by every handler function at the very end
+// of their execution phase, even if the action panics. Magic Finally methods
+// of the embedded controllers are called first.
+func (t tSecond) Finally(c *contr.Second, r *http.Request) {
+
+	Shared.Finally(c.Shared, r)
+
+}
+
+// Index is a handler that was generated automatically.
+// It calls Before, After (unless they are skipped), Finally methods, and Index action found at
+// github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers/app.go
+// in appropriate order.
+//
+// Index is an action of Second.
+//@get /second
+func (t tSecond) Index(w http.ResponseWriter, r *http.Request) {
+	var h http.Handler
+	c := Second.New(w, r, "Second", "Index")
+
+	defer Second.Finally(c, r)
+	defer func() {
+		if v := recover(); v != nil {
+			if h = Second.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+		}
+		if h == nil {
+			return
+		}
+
+		// Panics of the handler that writes the response
+		// are passed to the magic Recover methods, too.
+		if v := serve(h, w, r); v != nil {
+			if h = Second.Recover(c, r, v); h == nil {
+				panic(v)
+			}
+			h.ServeHTTP(w, r)
+		}
+	}()
+
+	defer Second.After(c, w, r)
+
+	if res := Second.Before(c, w, r); res != nil {
+		h = res
+		return
+	}
+
+	if res := c.Index(); res != nil {
+		h = res
+		return
+	}
+}
+
+func initSecond(shadowed []string) (rs []struct {
+	Method, Pattern, Label string
+	Handler                http.HandlerFunc
+}) {
+	context["Second"] = []string{"Index"}
+
+	if !skipped(shadowed, "github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/controllers.Second.Index") {
+		rs = append(rs, []struct {
+			Method, Pattern, Label string
+			Handler                http.HandlerFunc
+		}{
+			{
+				Method:  "GET",
+				Pattern: "/second",
+				Label:   "",
+				Handler: Second.Index,
+			},
+		}...)
+	}
+	return
+}
+
+func init() {
+	_ = strconv.MeaningOfLife
+}
@@ -0,0 +1,48 @@
+// Package inherit tests routes of the handlers generated
+// for controllers that embed parents.
+package inherit
+
+//go:generate goal generate handlers --input ./controllers --output ./handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goaltools/goal/tools/generate/handlers/testdata/inherit/handlers"
+)
+
+func TestInit(t *testing.T) {
+	n, bodies, tagged := map[string]int{}, map[string]string{}, map[string]bool{}
+	for _, r := range handlers.Init() {
+		n[r.Pattern]++
+		w := httptest.NewRecorder()
+		r.Handler(w, httptest.NewRequest(r.Method, r.Pattern, nil))
+		tagged[r.Pattern] = w.Header().Get("X-Tagged") == "true"
+		bodies[r.Pattern] = w.Body.String()
+	}
+	for _, p := range []string{"/app", "/local", "/lib/remote", "/list"} {
+		if !tagged[p] {
+			t.Errorf(`Handler of "%s" is expected to be wrapped with middleware of App.`, p)
+		}
+	}
+	for p, body := range map[string]string{
+		"/app":        "app",
+		"/local":      "local",
+		"/lib/remote": "remote",
+		"/list":       "list",
+		"/first":      "first",
+		"/second":     "second",
+		"/shared":     "shared",
+	} {
+		if n[p] != 1 || bodies[p] != body {
+			t.Errorf(`Route "%s" is expected to be registered once and respond with "%s", got %d, "%s".`, p, body, n[p], bodies[p])
+		}
+	}
+
+	// Actions of the parents that are shadowed must not be served.
+	for _, p := range []string{"/local/index", "/lib/remote/index", "/pager/ping"} {
+		if n[p] != 0 {
+			t.Errorf(`Route "%s" is shadowed and is not expected to be registered, got %d.`, p, n[p])
+		}
+	}
+}
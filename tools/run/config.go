@@ -23,7 +23,7 @@ type conf struct {
 
 // parseConf parses a requested file and returns
 // it in a form of conf structure.
-func parseConf(file string) *conf {
+func parseConf(file string) (*conf, error) {
 	var err error
 	c := &conf{
 		watch: map[string]func(){},
@@ -32,34 +32,34 @@ func parseConf(file string) *conf {
 	// Parse the configuration file..
 	c.m, err = parseFile(file)
 	if err != nil {
-		log.Error.Panic(err)
+		return nil, err
 	}
 
 	// Extract init tasks.
 	init, err := parseSlice(c.m, initSection)
 	if err != nil {
-		log.Error.Panic(err)
+		return nil, err
 	}
 	c.init, err = c.processTasksFn(init, initSection)
 	if err != nil {
-		log.Error.Panic(err)
+		return nil, err
 	}
 
 	// Extract patterns and tasks from watch section of config file.
 	watch, err := parseMap(c.m, watchSection)
 	if err != nil {
-		log.Error.Panic(err)
+		return nil, err
 	}
 	for pattern, tasks := range watch {
 		section := watchSection + ":" + pattern // It is used for debug messages.
 		c.watch[pattern], err = c.processTasksFn(tasks, section)
 		if err != nil {
-			log.Error.Panic(err)
+			return nil, err
 		}
 	}
 
 	log.Trace.Printf(`Config file "%s" has been parsed.`, file)
-	return c
+	return c, nil
 }
 
 // processTasksFn gets a list of tasks, processing them
@@ -93,7 +93,9 @@ func (c *conf) processTaskFn(task, section string) (func(), error) {
 
 	switch name {
 	case "/start":
-		assertSingleNonLoopArg(name, section, args)
+		if err := checkSingleNonLoopArg(name, section, args); err != nil {
+			return nil, err
+		}
 		lst, err := c.listSection(name, args[0])
 		if err != nil {
 			return nil, err
@@ -102,7 +104,9 @@ func (c *conf) processTaskFn(task, section string) (func(), error) {
 			start(lst)
 		}, nil
 	case "/run":
-		assertSingleNonLoopArg(name, section, args)
+		if err := checkSingleNonLoopArg(name, section, args); err != nil {
+			return nil, err
+		}
 		lst, err := c.listSection(name, args[0])
 		if err != nil {
 			return nil, err
@@ -111,7 +115,9 @@ func (c *conf) processTaskFn(task, section string) (func(), error) {
 			run(lst)
 		}, nil
 	case "/single":
-		assertSingleNonLoopArg(name, section, args)
+		if err := checkSingleNonLoopArg(name, section, args); err != nil {
+			return nil, err
+		}
 		lst, err := c.listSection(name, args[0])
 		if err != nil {
 			return nil, err
@@ -151,16 +157,17 @@ func parseTask(s string) (string, []string) {
 	return ps[0], as
 }
 
-// assertSingleNonLoopArg gets a section and a list of arguments
+// checkSingleNonLoopArg gets a section and a list of arguments
 // and makes sure the number of arguments is one and it is
 // not equal to the current section.
-func assertSingleNonLoopArg(name, section string, args []string) {
+func checkSingleNonLoopArg(name, section string, args []string) error {
 	if l := len(args); l != 1 {
-		log.Error.Panicf(`%s: Incorrect number of arguments. Expected 1, got %d.`, name, l)
+		return fmt.Errorf(`%s: incorrect number of arguments, expected 1, got %d`, name, l)
 	}
 	if args[0] == section {
-		log.Error.Panicf(`%s: Use of "%s" as argument is not possible, loops are not allowed.`, name, section)
+		return fmt.Errorf(`%s: use of "%s" as argument is not possible, loops are not allowed`, name, section)
 	}
+	return nil
 }
 
 // listSection gets a section, makes sure it is a list
@@ -6,57 +6,69 @@ import (
 )
 
 func TestParseConf_IncorrectWatchSection(t *testing.T) {
-	defer expectPanic(`Reading a conf file with incorrect "watch" section. Panic expected.`)
-	parseConf("./testdata/configs/incorrect_watch.yml")
+	if _, err := parseConf("./testdata/configs/incorrect_watch.yml"); err == nil {
+		t.Error(`Reading a conf file with incorrect "watch" section. Error expected.`)
+	}
 }
 
 func TestParseConf_RunTextSection(t *testing.T) {
-	defer expectPanic(`/run's first argument should be a section containing a list. Got text, panic expected.`)
-	parseConf("./testdata/configs/run_not_list_section.yml")
+	if _, err := parseConf("./testdata/configs/run_not_list_section.yml"); err == nil {
+		t.Error(`/run's first argument should be a section containing a list. Got text, error expected.`)
+	}
 }
 
 func TestParseConf_StartTextSection(t *testing.T) {
-	defer expectPanic(`/start's first argument should be a section containing a list. Got text, panic expected.`)
-	parseConf("./testdata/configs/start_not_list_section.yml")
+	if _, err := parseConf("./testdata/configs/start_not_list_section.yml"); err == nil {
+		t.Error(`/start's first argument should be a section containing a list. Got text, error expected.`)
+	}
 }
 
 func TestParseConf_SingleTextSection(t *testing.T) {
-	defer expectPanic(`/single's first argument should be a section containing a list. Got text, panic expected.`)
-	parseConf("./testdata/configs/single_not_list_section.yml")
+	if _, err := parseConf("./testdata/configs/single_not_list_section.yml"); err == nil {
+		t.Error(`/single's first argument should be a section containing a list. Got text, error expected.`)
+	}
 }
 
 func TestParseConf_RunIncorrectArgsNum(t *testing.T) {
-	defer expectPanic(`/run expects one argument. Got a few of them, panic expected.`)
-	parseConf("./testdata/configs/run_incorrect_args_number.yml")
+	if _, err := parseConf("./testdata/configs/run_incorrect_args_number.yml"); err == nil {
+		t.Error(`/run expects one argument. Got a few of them, error expected.`)
+	}
 }
 
 func TestParseConf_StartIncorrectArgsNum(t *testing.T) {
-	defer expectPanic(`/start expects one argument. Got a few of them, panic expected.`)
-	parseConf("./testdata/configs/start_incorrect_args_number.yml")
+	if _, err := parseConf("./testdata/configs/start_incorrect_args_number.yml"); err == nil {
+		t.Error(`/start expects one argument. Got a few of them, error expected.`)
+	}
 }
 
 func TestParseConf_SingleIncorrectArgsNum(t *testing.T) {
-	defer expectPanic(`/single expects one argument. Got a few of them, panic expected.`)
-	parseConf("./testdata/configs/single_incorrect_args_number.yml")
+	if _, err := parseConf("./testdata/configs/single_incorrect_args_number.yml"); err == nil {
+		t.Error(`/single expects one argument. Got a few of them, error expected.`)
+	}
 }
 
 func TestParseConf_PassIncorrectArgsNum(t *testing.T) {
-	defer expectPanic(`/pass expects no arguments. Got a few of them, panic expected.`)
-	parseConf("./testdata/configs/pass_incorrect_args_number.yml")
+	if _, err := parseConf("./testdata/configs/pass_incorrect_args_number.yml"); err == nil {
+		t.Error(`/pass expects no arguments. Got a few of them, error expected.`)
+	}
 }
 
 func TestParseConf_EmptyWatch(t *testing.T) {
-	defer expectPanic(`No empty configuration files are allowed, panic expected.`)
-	parseConf("./testdata/configs/empty_watch.yml")
+	if _, err := parseConf("./testdata/configs/empty_watch.yml"); err == nil {
+		t.Error(`No empty configuration files are allowed, error expected.`)
+	}
 }
 
 func TestParseConf_LoopSection(t *testing.T) {
-	defer expectPanic(`Loops in configuration files are not allowed. Panic expected.`)
-	parseConf("./testdata/configs/loop_section.yml")
+	if _, err := parseConf("./testdata/configs/loop_section.yml"); err == nil {
+		t.Error(`Loops in configuration files are not allowed. Error expected.`)
+	}
 }
 
 func TestParseConf(t *testing.T) {
-	parseConf("./testdata/configs/correct_config.yml")
+	if _, err := parseConf("./testdata/configs/correct_config.yml"); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestParseTask(t *testing.T) {
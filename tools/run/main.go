@@ -7,16 +7,17 @@
 package run
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
 
 	"github.com/goaltools/goal/internal/log"
+	"github.com/goaltools/goal/internal/modpath"
 	"github.com/goaltools/goal/internal/watcher"
 	"github.com/goaltools/goal/utils/tool"
 
-	"github.com/conveyer/importpath"
 	"gopkg.in/fsnotify.v1"
 )
 
@@ -43,22 +44,26 @@ directories are modified, some tasks are expected to be executed.
 var (
 	notify  = make(chan os.Signal, 1)
 	restart = make(chan bool, 1)
+
+	// failed is used by the configuration file watcher
+	// to report errors that require termination.
+	failed = make(chan error, 1)
 )
 
 // main is an entry point of the "run" subcommand (tool).
-func main(hs []tool.Handler, i int, args tool.Data) {
+func main(hs []tool.Handler, i int, args tool.Data) error {
 	// The first argument in the list is a path.
 	// If it's missing use an empty string instead.
 	p := args.GetDefault(0, "")
 
 	// Determine import path and absolute path of the project to run.
-	imp, err := importpath.Clean(p)
+	imp, err := modpath.Clean(p)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
-	dir, err := importpath.ToPath(imp)
+	dir, err := modpath.ToPath(imp)
 	if err != nil {
-		log.Error.Panic(err)
+		return err
 	}
 
 	// Prepare a path of configuration file.
@@ -68,16 +73,18 @@ func main(hs []tool.Handler, i int, args tool.Data) {
 	go instanceController()
 
 	// Start a configuration file watcher.
-	go configDaemon(imp, cf)
+	// It is stopped when we are done.
+	quit := make(chan bool)
+	defer close(quit)
+	go configDaemon(imp, cf, quit)
 
-	// Show user friendly errors and terminate subprograms
-	// in case of panics.
+	// Terminate subprograms when we are done.
 	defer func() {
 		channel <- message{
 			action: "exit",
 		}
 		<-stopped
-		log.Trace.Panicln("Application has been terminated.")
+		log.Trace.Println("Application has been terminated.")
 	}()
 
 	// Execute all commands from the requested directory.
@@ -93,10 +100,15 @@ func main(hs []tool.Handler, i int, args tool.Data) {
 
 	// Cleaning up after we are done.
 	signal.Notify(notify, os.Interrupt, syscall.SIGTERM)
-	<-notify
+	select {
+	case <-notify:
+		return nil
+	case err := <-failed:
+		return err
+	}
 }
 
-func configDaemon(imp, file string) {
+func configDaemon(imp, file string, quit <-chan bool) {
 	var watchers []*fsnotify.Watcher
 
 	// closeWatchers is iterating over available watchers
@@ -110,8 +122,13 @@ func configDaemon(imp, file string) {
 	defer closeWatchers() // Close watchers when we are done.
 
 	for {
-		// Wait till we are asked to reload the config file.
-		<-restart
+		// Wait till we are asked to reload the config file
+		// or to stop.
+		select {
+		case <-restart:
+		case <-quit:
+			return
+		}
 
 		// Closing old watchers to create new ones.
 		closeWatchers()
@@ -119,18 +136,20 @@ func configDaemon(imp, file string) {
 		// Make sure configuration file does exist.
 		_, err := os.Stat(file)
 		if err != nil || os.IsNotExist(err) {
-			log.Error.Printf(
-				`Are you sure "%s" is a path of goal project?
-"%s" file is missing.`, imp, file,
+			failed <- fmt.Errorf(
+				`are you sure "%s" is a path of goal project? "%s" file is missing`, imp, file,
 			)
-			notify <- syscall.SIGTERM
 			return
 		}
 
 		// Parsing configuration file and extracting the values
 		// we need.
 		log.Trace.Printf(`Starting to parse "%s"...`, file)
-		c := parseConf(file)
+		c, err := parseConf(file)
+		if err != nil {
+			failed <- fmt.Errorf(`cannot parse "%s": %v`, file, err)
+			return
+		}
 
 		// Start init tasks.
 		c.init()
@@ -20,8 +20,6 @@ func TestMain_TestData(t *testing.T) {
 	createConfig(t)
 	createdFile := make(chan bool, 1)
 
-	defer expectPanic(`Application was terminated, panic expected.`)
-
 	// Paths relative to the root directory are used here.
 	defer os.Remove("tmp.test")
 	go func() {
@@ -33,12 +31,12 @@ func TestMain_TestData(t *testing.T) {
 		<-createdFile
 		notify <- syscall.SIGTERM
 	}()
-	main(handlers, 0, tool.Data{"./testdata/configs"})
+	if err := main(handlers, 0, tool.Data{"./testdata/configs"}); err != nil {
+		t.Errorf(`Application was terminated, no error expected. Got "%v".`, err)
+	}
 }
 
 func TestMain_TestData2(t *testing.T) {
-	defer expectPanic(`Application was terminated, panic expected.`)
-
 	createConfig(t)
 	time.Sleep(time.Second * 1)
 
@@ -48,24 +46,33 @@ func TestMain_TestData2(t *testing.T) {
 		time.Sleep(time.Second * 4)
 		notify <- syscall.SIGTERM
 	}()
-	main(handlers, 0, tool.Data{"github.com/goaltools/goal/tools/run/testdata/configs"})
+	if err := main(handlers, 0, tool.Data{"github.com/goaltools/goal/tools/run/testdata/configs"}); err != nil {
+		t.Errorf(`Application was terminated, no error expected. Got "%v".`, err)
+	}
 }
 
 func TestMain_IncorrectConfig(t *testing.T) {
-	defer expectPanic(`A directory without configuration file. Panic expected.`)
-	notify <- syscall.SIGTERM
-
 	// Directory without config file.
-	main(handlers, 0, tool.Data{"./testdata"})
+	if err := main(handlers, 0, tool.Data{"./testdata"}); err == nil {
+		t.Errorf(`A directory without configuration file. Error expected.`)
+	}
+}
+
+func TestMain_MalformedConfig(t *testing.T) {
+	// Directory with a configuration file that cannot be parsed.
+	if err := main(handlers, 0, tool.Data{"./testdata/malformed"}); err == nil {
+		t.Errorf(`A directory with malformed configuration file. Error expected.`)
+	}
 }
 
 func TestMain(t *testing.T) {
-	defer expectPanic(`Application was terminated, panic expected.`)
 	go func() {
 		time.Sleep(time.Second * 4)
 		notify <- syscall.SIGTERM
 	}()
-	main(handlers, 0, tool.Data{"github.com/goaltools/goal/internal/skeleton"})
+	if err := main(handlers, 0, tool.Data{"github.com/goaltools/goal/internal/skeleton"}); err != nil {
+		t.Errorf(`Application was terminated, no error expected. Got "%v".`, err)
+	}
 }
 
 func createConfig(t *testing.T) []byte {
@@ -86,10 +93,4 @@ func createConfig(t *testing.T) []byte {
 	return bs
 }
 
-func expectPanic(msg string) {
-	if err := recover(); err == nil {
-		panic(msg)
-	}
-}
-
 var handlers = []tool.Handler{Handler}
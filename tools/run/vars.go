@@ -16,3 +16,13 @@ func replaceVars(s string) string {
 	}
 	return s
 }
+
+// Vars returns special variables that are replaced in tasks
+// and their values.
+func Vars() map[string]string {
+	m := map[string]string{}
+	for k, v := range varList {
+		m[k] = v
+	}
+	return m
+}
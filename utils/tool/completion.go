@@ -0,0 +1,293 @@
+package tool
+
+import (
+	"flag"
+	"fmt"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goaltools/goal/internal/modpath"
+)
+
+const (
+	// pathArg is a part of Handler.Usage that means the handler
+	// expects an import path as an argument.
+	pathArg = "{path}"
+
+	// completionName is a name of the "completion" subcommand (tool).
+	completionName = "completion"
+)
+
+// CompletionHandler is an instance of "completion" subcommand (tool).
+// It is registered by NewContext automatically.
+var CompletionHandler = Handler{
+	Run: completion,
+
+	Name:  completionName,
+	Usage: "bash|zsh|fish",
+	Info:  "generate a shell completion script",
+	Desc: `Completion prints a script that enables completion of commands,
+their flags, and import paths for the requested shell.
+The script asks the tool itself for the list of candidates, so it
+is always up to date with the installed version.
+
+Examples:
+	source <(cli completion bash)
+	cli completion zsh > "${fpath[1]}/_cli"
+	cli completion fish > ~/.config/fish/completions/cli.fish
+`,
+}
+
+// candidates is a flag that is used by the completion scripts.
+// If it is true, words that follow the flag are completed
+// and the candidates are printed instead of a script.
+var candidates *bool
+
+// completion is an entry function of the "completion" subcommand (tool).
+func completion(hs []Handler, i int, args Data) error {
+	if *candidates {
+		for _, c := range complete(withPlugins(hs), args) {
+			fmt.Fprintln(output, c)
+		}
+		return nil
+	}
+
+	script, ok := scripts[args.GetDefault(0, "")]
+	if !ok {
+		return UsageError(`unsupported shell "%s", expected one of: bash, zsh, fish`, args.GetDefault(0, ""))
+	}
+	fmt.Fprintf(output, script, name())
+	return nil
+}
+
+// complete gets a list of words that are typed after the name of
+// the executable and returns candidates for the last one.
+// The last word is expected to be incomplete (it may be an empty string).
+func complete(hs []Handler, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	done, cur := words[:len(words)-1], words[len(words)-1]
+
+	// Check whether a command has already been typed.
+	for i := 0; i < len(hs); i++ {
+		as, ok := hs[i].Requested(done)
+		if !ok {
+			continue
+		}
+
+		// Names of commands are arguments of help.
+		if hs[i].Name == HelpHandler.Name {
+			return complete(hs, append(as, cur))
+		}
+		return completeArgs(hs[i], as, cur)
+	}
+
+	// Otherwise, complete a word of a command name.
+	res := []string{}
+	seen := map[string]bool{}
+	for i := 0; i < len(hs); i++ {
+		ws := strings.Split(hs[i].Name, commandWordSep)
+		if len(ws) <= len(done) || strings.Join(ws[:len(done)], commandWordSep) != strings.Join(done, commandWordSep) {
+			continue
+		}
+		w := ws[len(done)]
+		if !strings.HasPrefix(w, cur) || seen[w] {
+			continue
+		}
+		seen[w] = true
+		res = append(res, w)
+	}
+	return res
+}
+
+// completeArgs returns candidates for the current word cur of
+// the arguments of the handler h. Arguments that are
+// already typed are passed as the second parameter.
+func completeArgs(h Handler, done []string, cur string) (res []string) {
+	// Complete names of the flags.
+	if strings.HasPrefix(cur, "-") {
+		n := strings.TrimLeft(cur, "-")
+		h.Flags.VisitAll(func(f *flag.Flag) {
+			if strings.HasPrefix(f.Name, n) {
+				res = append(res, "--"+f.Name)
+			}
+		})
+		return
+	}
+
+	// Values of the flags are not known.
+	if l := len(done); l > 0 && expectsValue(h, done[l-1]) {
+		return
+	}
+
+	// Names of shells are arguments of completion.
+	if h.Name == completionName {
+		for k := range scripts {
+			if strings.HasPrefix(k, cur) {
+				res = append(res, k)
+			}
+		}
+		sort.Strings(res)
+		return
+	}
+
+	// Complete import paths if the handler expects them.
+	if strings.Contains(h.Usage, pathArg) {
+		return importPaths(cur)
+	}
+	return
+}
+
+// expectsValue checks whether the word is a flag of the handler
+// that requires a value, i.e. the next word is that value.
+func expectsValue(h Handler, w string) bool {
+	if !strings.HasPrefix(w, "-") || strings.Contains(w, "=") {
+		return false
+	}
+	f := h.Flags.Lookup(strings.TrimLeft(w, "-"))
+	if f == nil {
+		return false
+	}
+	bf, ok := f.Value.(interface {
+		IsBoolFlag() bool
+	})
+	return !ok || !bf.IsBoolFlag()
+}
+
+// importPaths returns directories that start with the requested
+// import path. Relative and absolute paths are completed using
+// the file system, other ones are looked up in "$GOPATH/src" and,
+// in module mode, in the current module and modules it requires.
+func importPaths(cur string) []string {
+	dir, prefix := path.Split(filepath.ToSlash(cur))
+
+	// Find out where the directories must be searched for.
+	roots := []string{filepath.FromSlash(dir)}
+	seen := map[string]bool{}
+	res := []string{}
+	if !strings.HasPrefix(cur, ".") && !filepath.IsAbs(cur) {
+		roots = []string{}
+		for _, p := range filepath.SplitList(build.Default.GOPATH) {
+			roots = append(roots, filepath.Join(p, "src", filepath.FromSlash(dir)))
+		}
+		if f := module(); f != nil {
+			if p, ok := f.ToPath(strings.TrimSuffix(dir, "/")); ok && dir != "" {
+				roots = append(roots, p)
+			}
+
+			// Import paths of the modules are completed one element at a time
+			// until the root of a module is reached.
+			mods := []string{f.Path}
+			for mod := range f.Require {
+				mods = append(mods, mod)
+			}
+			for _, mod := range mods {
+				if !strings.HasPrefix(mod, dir) {
+					continue
+				}
+				n := mod[len(dir):]
+				if i := strings.Index(n, "/"); i >= 0 {
+					n = n[:i]
+				}
+				if p := dir + n + "/"; strings.HasPrefix(n, prefix) && !seen[p] {
+					seen[p] = true
+					res = append(res, p)
+				}
+			}
+		}
+	}
+
+	for i := range roots {
+		fis, _ := ioutil.ReadDir(roots[i])
+		for _, fi := range fis {
+			n := fi.Name()
+			if !fi.IsDir() || !strings.HasPrefix(n, prefix) {
+				continue
+			}
+
+			// Hidden directories are shown only if requested explicitly.
+			if strings.HasPrefix(n, ".") && !strings.HasPrefix(prefix, ".") {
+				continue
+			}
+			if p := dir + n + "/"; !seen[p] {
+				seen[p] = true
+				res = append(res, p)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// module returns the go.mod file of the module the current
+// directory belongs to or nil if module mode is disabled
+// or there is no such module.
+func module() *modpath.File {
+	if !modpath.Enabled() {
+		return nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	f, _ := modpath.Find(wd)
+	return f
+}
+
+func init() {
+	candidates = CompletionHandler.Flags.Bool(
+		"complete", false, "print candidates for completion of the words that follow (used by the scripts)",
+	)
+}
+
+// scripts are completion scripts for supported shells.
+// Arguments to format are:
+//
+//	[1]: name of the executable.
+var scripts = map[string]string{
+	"bash": `# bash completion for %[1]s.
+_%[1]s() {
+	local IFS=$'\n'
+	COMPREPLY=($(%[1]s completion --complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+	if [[ ${#COMPREPLY[@]} -eq 1 && ${COMPREPLY[0]} == */ ]]; then
+		compopt -o nospace
+	fi
+}
+complete -o default -F _%[1]s %[1]s
+`,
+	"zsh": `#compdef %[1]s
+# zsh completion for %[1]s.
+_%[1]s() {
+	local -a cs
+	local c
+	cs=("${(@f)$(%[1]s completion --complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	cs=(${cs:#})
+	if (( ${#cs} == 0 )); then
+		_files
+		return
+	fi
+	for c in "${cs[@]}"; do
+		if [[ $c == */ ]]; then
+			compadd -S '' -- "$c"
+		else
+			compadd -- "$c"
+		fi
+	done
+}
+compdef _%[1]s %[1]s
+`,
+	"fish": `# fish completion for %[1]s.
+function __%[1]s_complete
+	set -l words (commandline -opc)
+	set -e words[1]
+	%[1]s completion --complete -- $words (commandline -ct) 2>/dev/null
+end
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`,
+}
@@ -0,0 +1,133 @@
+package tool
+
+import (
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	hs := NewContext(testHandlers()...).list
+	for cmd, exp := range map[string][]string{
+		"":                          {"new", "generate", "completion", "help"},
+		"ge":                        {"generate"},
+		"generate ":                 {"stuff", "listing"},
+		"generate s":                {"stuff"},
+		"generate stuff --o":        {"--output"},
+		"generate stuff -":          {"--output"},
+		"generate stuff --output ":  nil,
+		"new --force ./testdata/xx": {},
+		"help gen":                  {"generate"},
+		"help generate l":           {"listing"},
+		"completion ":               {"bash", "fish", "zsh"},
+		"completion z":              {"zsh"},
+		"unknown ":                  {},
+	} {
+		if res := complete(hs, strings.Split(cmd, commandWordSep)); !reflect.DeepEqual(res, exp) {
+			t.Errorf(`"%s": expected candidates %#v, got %#v.`, cmd, exp, res)
+		}
+	}
+}
+
+func TestCompleteImportPaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goal-completion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, d := range []string{"github.com/user/project", "github.com/user/.hidden", "gopkg.in/x"} {
+		os.MkdirAll(filepath.Join(dir, "src", filepath.FromSlash(d)), 0755)
+	}
+	old := build.Default.GOPATH
+	build.Default.GOPATH = dir
+	defer func() {
+		build.Default.GOPATH = old
+	}()
+
+	hs := NewContext(testHandlers()...).list
+	for cmd, exp := range map[string][]string{
+		"new g":                {"github.com/", "gopkg.in/"},
+		"new github.com/u":     {"github.com/user/"},
+		"new github.com/user/": {"github.com/user/project/"},
+		"new ./testdata/":      {},
+	} {
+		if res := complete(hs, strings.Split(cmd, commandWordSep)); !reflect.DeepEqual(res, exp) {
+			t.Errorf(`"%s": expected candidates %#v, got %#v.`, cmd, exp, res)
+		}
+	}
+}
+
+func TestCompleteImportPaths_Module(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goal-completion")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, d := range []string{"app/cmd/server", "app/.git", "lib/text"} {
+		os.MkdirAll(filepath.Join(dir, filepath.FromSlash(d)), 0755)
+	}
+	ioutil.WriteFile(filepath.Join(dir, "app", "go.mod"), []byte(`module example.com/user/app
+
+go 1.18
+
+require golang.org/x/lib v1.0.0
+
+replace golang.org/x/lib => ../lib
+`), 0644)
+
+	old, _ := os.Getwd()
+	os.Chdir(filepath.Join(dir, "app", "cmd"))
+	defer os.Chdir(old)
+	defer os.Setenv("GO111MODULE", os.Getenv("GO111MODULE"))
+	os.Setenv("GO111MODULE", "on")
+	oldGopath := build.Default.GOPATH
+	build.Default.GOPATH = filepath.Join(dir, "gopath")
+	defer func() {
+		build.Default.GOPATH = oldGopath
+	}()
+
+	hs := NewContext(testHandlers()...).list
+	for cmd, exp := range map[string][]string{
+		"new e":                         {"example.com/"},
+		"new example.com/user/":         {"example.com/user/app/"},
+		"new example.com/user/app/":     {"example.com/user/app/cmd/"},
+		"new example.com/user/app/cmd/": {"example.com/user/app/cmd/server/"},
+		"new golang.org/x/":             {"golang.org/x/lib/"},
+		"new golang.org/x/lib/t":        {"golang.org/x/lib/text/"},
+		"new github.com/":               {},
+	} {
+		if res := complete(hs, strings.Split(cmd, commandWordSep)); !reflect.DeepEqual(res, exp) {
+			t.Errorf(`"%s": expected candidates %#v, got %#v.`, cmd, exp, res)
+		}
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	c := NewContext(testHandlers()...)
+	for _, sh := range []string{"bash", "zsh", "fish"} {
+		buf := captureOutput(t, func() {
+			if err := c.Run([]string{"completion", sh}); err != nil {
+				t.Errorf(`"%s": unexpected error "%v".`, sh, err)
+			}
+		})
+		if !strings.Contains(buf, "completion --complete --") {
+			t.Errorf(`"%s": script is expected to request candidates, got:%s`, sh, buf)
+		}
+	}
+
+	buf := captureOutput(t, func() {
+		c.Run([]string{"completion", "--complete", "--", "generate", "s"})
+	})
+	if buf != "\nstuff\n" {
+		t.Errorf(`Candidates expected to be printed one per line, got:%s`, buf)
+	}
+	*candidates = false
+
+	if err := c.Run([]string{"completion", "powershell"}); ExitCode(err) != ExitUsage {
+		t.Errorf(`Unsupported shell: usage error expected, got "%v".`, err)
+	}
+}
@@ -0,0 +1,49 @@
+package tool
+
+import (
+	"fmt"
+)
+
+// Exit codes that represent results of a subcommand's (tool's) execution.
+const (
+	ExitOK       = 0 // Command has been completed successfully.
+	ExitFailure  = 1 // Command has returned an error, e.g. generation has failed.
+	ExitUsage    = 2 // Unknown command, incorrect arguments or flags.
+	ExitInternal = 3 // Command has panicked.
+)
+
+// Error is an error that is returned by Context.Run.
+// It contains an exit code the program is expected to be terminated with.
+type Error struct {
+	Code  int    // Exit code, e.g. ExitUsage.
+	Err   error  // The original error.
+	Stack []byte // Stack trace of the goroutine in case of a panic.
+}
+
+// Error is a method that's required for Error type
+// to be an implementation of error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// UsageError returns an error with ExitUsage code.
+// Handlers may use it to report incorrect arguments.
+func UsageError(format string, a ...interface{}) error {
+	return &Error{
+		Code: ExitUsage,
+		Err:  fmt.Errorf(format, a...),
+	}
+}
+
+// ExitCode returns an exit code associated with the error.
+// Nil errors are associated with ExitOK, errors of type
+// other than *Error with ExitFailure.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Code
+	}
+	return ExitFailure
+}
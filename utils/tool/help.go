@@ -0,0 +1,137 @@
+package tool
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// output is a writer the help messages are printed to.
+var output io.Writer = os.Stdout
+
+// HelpHandler is an instance of "help" subcommand (tool).
+// It is registered by NewContext automatically.
+var HelpHandler = Handler{
+	Run: help,
+
+	Name:  "help",
+	Usage: "[command]",
+	Info:  "show information about a command",
+	Desc: `Help shows a list of available commands if no arguments are received.
+Otherwise, it shows usage, description, and flags of the requested command.
+If the requested name is a group of commands (e.g. "generate"),
+its subcommands are listed. External commands (executables
+named "cli-<word>[-<word>]" that are found in PATH) are listed too.
+
+Examples:
+	cli help
+	cli help generate
+	cli help generate handlers
+`,
+}
+
+// help is an entry function of the "help" subcommand (tool).
+func help(hs []Handler, i int, args Data) error {
+	hs = withPlugins(hs)
+
+	// If no command is requested, show all of them.
+	if len(args) == 0 {
+		fmt.Fprintf(output, usageMsg, name())
+		printList(hs)
+		fmt.Fprintf(output, moreMsg, name())
+		return nil
+	}
+
+	// Check whether a specific handler is requested.
+	for j := 0; j < len(hs); j++ {
+		if as, ok := hs[j].Requested(args); ok && len(as) == 0 {
+			printHandler(hs[j])
+			return nil
+		}
+	}
+
+	// Otherwise, it may be a group of commands, e.g. "generate".
+	// Find all handlers whose names start with the requested words.
+	prefix := strings.Join(args, commandWordSep) + commandWordSep
+	sub := []Handler{}
+	for j := 0; j < len(hs); j++ {
+		if strings.HasPrefix(hs[j].Name, prefix) {
+			sub = append(sub, hs[j])
+		}
+	}
+	if len(sub) > 0 {
+		fmt.Fprintf(output, groupMsg, strings.Join(args, commandWordSep))
+		printList(sub)
+		fmt.Fprintf(output, moreMsg, name())
+		return nil
+	}
+
+	return UsageError(`unknown help topic "%s"%s`, strings.Join(args, commandWordSep), suggest(hs, args))
+}
+
+// printList prints names of the handlers and their one line descriptions
+// as an aligned list.
+func printList(hs []Handler) {
+	// Find the longest name to align the descriptions.
+	l := 0
+	for i := 0; i < len(hs); i++ {
+		if n := len(hs[i].Name); n > l {
+			l = n
+		}
+	}
+
+	for i := 0; i < len(hs); i++ {
+		fmt.Fprintf(output, "\t%-*s  %s\n", l, hs[i].Name, hs[i].Info)
+	}
+}
+
+// printHandler prints usage, detailed description, and flags
+// of the requested handler.
+func printHandler(h Handler) {
+	fmt.Fprintf(output, "Usage: %s %s %s\n", name(), h.Name, h.Usage)
+	if len(h.Aliases) > 0 {
+		fmt.Fprintf(output, "Aliases: %s\n", strings.Join(h.Aliases, ", "))
+	}
+	fmt.Fprintln(output)
+	if h.Desc != "" {
+		fmt.Fprintln(output, strings.TrimRight(h.Desc, "\n"))
+	} else {
+		fmt.Fprintln(output, h.Info)
+	}
+
+	// Print flags of the handler if there are any.
+	n := 0
+	h.Flags.VisitAll(func(f *flag.Flag) {
+		if n == 0 {
+			fmt.Fprintln(output, "\nFlags:")
+		}
+		n++
+		fmt.Fprintf(output, "\t--%s", f.Name)
+		if f.DefValue != "" {
+			fmt.Fprintf(output, " (default %q)", f.DefValue)
+		}
+		fmt.Fprintf(output, "\n\t\t%s\n", f.Usage)
+	})
+}
+
+// name returns the name of the executable that is used
+// in the help messages, e.g. "goal".
+func name() string {
+	return filepath.Base(os.Args[0])
+}
+
+var usageMsg = `Usage:
+	%s command [arguments]
+
+The commands are:
+`
+
+var groupMsg = `The "%s" commands are:
+`
+
+var moreMsg = `
+Use "%s help [command]" for more information about a command.
+`
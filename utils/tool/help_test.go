@@ -0,0 +1,95 @@
+package tool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHelp(t *testing.T) {
+	c := NewContext(testHandlers()...)
+	for cmd, exp := range map[string][]string{
+		"help": {
+			"new", "create a new thing",
+			"generate stuff", "generate some stuff",
+			"help", HelpHandler.Info,
+		},
+		"help new": {
+			"Usage: ", "new {path}", "New creates a new thing.",
+			"--force", `(default "false")`, "overwrite existing files",
+		},
+		"help generate": {
+			`The "generate" commands are:`,
+			"generate stuff", "generate listing",
+		},
+		"help generate stuff": {
+			"generate stuff [flags]", "--output", `(default "./assets")`,
+		},
+	} {
+		buf := captureOutput(t, func() {
+			if err := c.Run(strings.Split(cmd, commandWordSep)); err != nil {
+				t.Errorf(`"%s": unexpected error "%v".`, cmd, err)
+			}
+		})
+		for i := range exp {
+			if !strings.Contains(buf, exp[i]) {
+				t.Errorf(`"%s": output is expected to contain "%s", got:%s`, cmd, exp[i], buf)
+			}
+		}
+	}
+}
+
+func TestHelp_GroupDoesNotListOtherCommands(t *testing.T) {
+	c := NewContext(testHandlers()...)
+	buf := captureOutput(t, func() {
+		c.Run([]string{"help", "generate"})
+	})
+	if strings.Contains(buf, "create a new thing") {
+		t.Errorf(`Only subcommands of "generate" are expected to be listed, got:%s`, buf)
+	}
+}
+
+func TestHelp_UnknownTopic(t *testing.T) {
+	c := NewContext(testHandlers()...)
+	err := c.Run([]string{"help", "something"})
+	if code := ExitCode(err); code != ExitUsage {
+		t.Errorf(`Unknown help topic: exit code %d expected, got %d ("%v").`, ExitUsage, code, err)
+	}
+}
+
+func testHandlers() []Handler {
+	hs := []Handler{
+		{
+			Run:   func(hs []Handler, i int, args Data) error { return nil },
+			Name:  "new",
+			Usage: "{path}",
+			Info:  "create a new thing",
+			Desc:  "New creates a new thing.\n",
+		},
+		{
+			Run:   func(hs []Handler, i int, args Data) error { return nil },
+			Name:  "generate stuff",
+			Usage: "[flags]",
+			Info:  "generate some stuff",
+		},
+		{
+			Run:  func(hs []Handler, i int, args Data) error { return nil },
+			Name: "generate listing",
+			Info: "generate a listing",
+		},
+	}
+	hs[0].Flags.Bool("force", false, "overwrite existing files")
+	hs[1].Flags.String("output", "./assets", "output directory")
+	return hs
+}
+
+func captureOutput(t *testing.T, fn func()) string {
+	var buf bytes.Buffer
+	old := output
+	output = &buf
+	defer func() {
+		output = old
+	}()
+	fn()
+	return "\n" + buf.String()
+}
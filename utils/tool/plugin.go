@@ -0,0 +1,125 @@
+package tool
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// pluginWordSep is a separator of words in a name of a plugin's executable.
+// For illustration, "goal-generate-stuff" is a plugin that is started
+// as "goal generate stuff".
+const pluginWordSep = "-"
+
+// plugins scans directories in the PATH environment variable looking for
+// external subcommands (aka plugins), i.e. executables that are named as
+// "goal-<word>[-<word>]". It returns them as a list of handlers.
+// Plugins with names of the builtin handlers hs are ignored.
+// Handlers with the bigger number of words in the name go first.
+func plugins(hs []Handler) (ps []Handler) {
+	prefix := pluginPrefix()
+	seen := map[string]bool{}
+	for i := range hs {
+		seen[hs[i].Name] = true
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		fis, _ := ioutil.ReadDir(dir)
+		for _, fi := range fis {
+			n, ok := pluginName(prefix, fi)
+			if !ok || seen[n] {
+				continue
+			}
+			seen[n] = true // The first executable found in PATH wins.
+
+			file := filepath.Join(dir, fi.Name())
+			ps = append(ps, Handler{
+				Run:   plugin(file),
+				Name:  n,
+				Usage: "[arguments]",
+				Info:  fmt.Sprintf("external command (%s)", file),
+				Desc: fmt.Sprintf(
+					"External command that is provided by \"%s\".\nAll arguments are passed to it as is.\n", file,
+				),
+			})
+		}
+	}
+
+	// Make sure the longest names are checked first, so "generate stuff"
+	// is preferred over "generate" if both are available.
+	sort.SliceStable(ps, func(i, j int) bool {
+		return strings.Count(ps[i].Name, commandWordSep) > strings.Count(ps[j].Name, commandWordSep)
+	})
+	return
+}
+
+// withPlugins returns a new list that consists of
+// the handlers hs and the plugins found in PATH.
+func withPlugins(hs []Handler) []Handler {
+	return append(append([]Handler{}, hs...), plugins(hs)...)
+}
+
+// pluginName gets a prefix of plugin executables and information
+// about a file. If the file is a plugin, its command name is returned
+// (e.g. "generate stuff" for "goal-generate-stuff") and true.
+// Otherwise, empty string and false.
+func pluginName(prefix string, fi os.FileInfo) (string, bool) {
+	n := fi.Name()
+	if fi.IsDir() || !strings.HasPrefix(n, prefix) {
+		return "", false
+	}
+
+	// Make sure the file is executable.
+	if runtime.GOOS == "windows" {
+		if strings.ToLower(filepath.Ext(n)) != ".exe" {
+			return "", false
+		}
+		n = n[:len(n)-len(filepath.Ext(n))]
+	} else if fi.Mode()&0111 == 0 {
+		return "", false
+	}
+
+	// Transform the rest of the name into a command name.
+	ws := strings.Split(n[len(prefix):], pluginWordSep)
+	for i := range ws {
+		if ws[i] == "" {
+			return "", false
+		}
+	}
+	return strings.Join(ws, commandWordSep), true
+}
+
+// plugin returns an entry function of the plugin handler that
+// starts the requested executable with the received arguments.
+// Exit code of the executable is used as the exit code of the handler.
+func plugin(file string) func(hs []Handler, i int, args Data) error {
+	return func(hs []Handler, i int, args Data) error {
+		cmd := exec.Command(file, args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if e, ok := err.(*exec.ExitError); ok {
+			code := e.ExitCode()
+			if code <= 0 { // E.g. the process has been killed by a signal.
+				code = ExitFailure
+			}
+			return &Error{
+				Code: code,
+				Err:  fmt.Errorf(`external command "%s" has failed: %v`, file, err),
+			}
+		}
+		return err
+	}
+}
+
+// pluginPrefix returns a prefix of the plugin executables,
+// e.g. "goal-".
+func pluginPrefix() string {
+	return strings.TrimSuffix(name(), ".exe") + pluginWordSep
+}
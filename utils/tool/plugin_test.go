@@ -0,0 +1,87 @@
+package tool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRun_Plugins(t *testing.T) {
+	dir, done := preparePlugins(t)
+	defer done()
+
+	c := NewContext(testHandlers()...)
+	out := filepath.Join(dir, "out.txt")
+	err := c.Run([]string{"generate", "ours", "--output", out, "x"})
+	if err != nil {
+		t.Errorf(`Plugin "generate ours" expected to be started, got "%v".`, err)
+	}
+	if res, _ := ioutil.ReadFile(out); string(res) != "--output "+out+" x\n" {
+		t.Errorf(`Arguments expected to be passed to the plugin as is, got "%s".`, res)
+	}
+
+	if err := c.Run([]string{"fail"}); ExitCode(err) != 5 {
+		t.Errorf(`Exit code of the plugin expected to be used, got "%v" (%d).`, err, ExitCode(err))
+	}
+	if err := c.Run([]string{"notexecutable"}); ExitCode(err) != ExitUsage {
+		t.Errorf(`Files that are not executable are not plugins, usage error expected. Got "%v".`, err)
+	}
+	if err := c.Run([]string{"new"}); err != nil {
+		t.Errorf(`Builtin handlers expected to be preferred over plugins, got "%v".`, err)
+	}
+}
+
+func TestHelp_Plugins(t *testing.T) {
+	_, done := preparePlugins(t)
+	defer done()
+
+	c := NewContext(testHandlers()...)
+	buf := captureOutput(t, func() {
+		c.Run([]string{"help", "generate"})
+	})
+	for _, exp := range []string{"generate stuff", "generate ours", "external command"} {
+		if !strings.Contains(buf, exp) {
+			t.Errorf(`Output is expected to contain "%s", got:%s`, exp, buf)
+		}
+	}
+	if strings.Contains(buf, "notexecutable") {
+		t.Errorf(`Files that are not executable are not plugins, got:%s`, buf)
+	}
+}
+
+// preparePlugins creates a temporary directory with plugins,
+// and uses it as PATH. The returned function must be called
+// to remove the directory and restore PATH.
+func preparePlugins(t *testing.T) (string, func()) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Shell scripts are used as plugins.")
+	}
+	dir, err := ioutil.TempDir("", "goal-plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := pluginPrefix()
+	for n, v := range map[string]struct {
+		content string
+		mode    os.FileMode
+	}{
+		prefix + "generate-ours": {"#!/bin/sh\necho \"$@\" > \"$2\"\n", 0755},
+		prefix + "fail":          {"#!/bin/sh\nexit 5\n", 0755},
+		prefix + "new":           {"#!/bin/sh\nexit 1\n", 0755},
+		prefix + "notexecutable": {"#!/bin/sh\n", 0644},
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, n), []byte(v.content), v.mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := os.Getenv("PATH")
+	os.Setenv("PATH", dir)
+	return dir, func() {
+		os.Setenv("PATH", old)
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,91 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDistance is the maximum edit distance between a requested
+// command and a name of a handler for the latter to be suggested.
+const maxDistance = 2
+
+// suggest gets a list of handlers and arguments of an unknown command.
+// It returns a message with names of the handlers that look similar to
+// the requested command, e.g. `, did you mean "generate handlers"?`.
+// If there are no such handlers, empty string is returned.
+func suggest(hs []Handler, args []string) string {
+	ns := []string{}
+	for i := 0; i < len(hs); i++ {
+		if similar(hs[i], args) {
+			ns = append(ns, fmt.Sprintf(`"%s"`, hs[i].Name))
+		}
+	}
+	if len(ns) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %s?", strings.Join(ns, " or "))
+}
+
+// similar checks whether the name or one of the aliases of the handler
+// is close to the first words of args or starts with them.
+func similar(h Handler, args []string) bool {
+	for _, n := range append([]string{h.Name}, h.Aliases...) {
+		// Compare the name with the same number of words of the command.
+		num := strings.Count(n, commandWordSep) + 1
+		if num > len(args) {
+			num = len(args)
+		}
+		cmd := strings.Join(args[:num], commandWordSep)
+
+		// Incomplete names of commands, e.g. "generate".
+		if strings.HasPrefix(n, cmd+commandWordSep) {
+			return true
+		}
+
+		// The shorter the name is, the less it is allowed to differ.
+		if distance(cmd, n) <= minInt(maxDistance, len(n)/2) {
+			return true
+		}
+	}
+	return false
+}
+
+// distance calculates the edit distance between two strings, i.e.
+// the minimum number of insertions, deletions, substitutions,
+// and transpositions of adjacent characters that are required to
+// transform one of them into another.
+func distance(a, b string) int {
+	// d[i][j] is the distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// minInt returns the smallest of the numbers.
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
@@ -0,0 +1,46 @@
+package tool
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	hs := []Handler{
+		{Name: "new", Aliases: []string{"n"}},
+		{Name: "run"},
+		{Name: "generate handlers", Aliases: []string{"g h"}},
+	}
+	for cmd, exp := range map[string]string{
+		"generate handler": `, did you mean "generate handlers"?`,
+		"generate":         `, did you mean "generate handlers"?`,
+		"genrate handlers": `, did you mean "generate handlers"?`,
+		"nwe ./app":        `, did you mean "new"?`,
+		"rnu":              `, did you mean "run"?`,
+		"g x":              `, did you mean "generate handlers"?`,
+		"x":                "",
+		"something":        "",
+	} {
+		if res := suggest(hs, strings.Split(cmd, commandWordSep)); res != exp {
+			t.Errorf(`"%s": expected "%s", got "%s".`, cmd, exp, res)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	for _, v := range []struct {
+		a, b string
+		exp  int
+	}{
+		{"", "", 0},
+		{"new", "", 3},
+		{"new", "new", 0},
+		{"new", "nwe", 1},
+		{"handler", "handlers", 1},
+		{"kitten", "sitting", 3},
+	} {
+		if d := distance(v.a, v.b); d != v.exp {
+			t.Errorf(`Distance between "%s" and "%s": expected %d, got %d.`, v.a, v.b, v.exp, d)
+		}
+	}
+}
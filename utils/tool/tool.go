@@ -3,9 +3,9 @@
 package tool
 
 import (
-	"errors"
 	"flag"
 	"fmt"
+	"runtime/debug"
 	"strings"
 )
 
@@ -25,7 +25,11 @@ type Data []string
 
 // NewContext gets a number of handlers as arguments, allocates
 // a new Context and returns it.
+// CompletionHandler and HelpHandler are added to the end
+// of the list automatically.
 func NewContext(handlers ...Handler) *Context {
+	handlers = append(handlers, CompletionHandler, HelpHandler)
+
 	// Allocate a new context with handlers
 	// as a list.
 	c := &Context{
@@ -47,31 +51,48 @@ func NewContext(handlers ...Handler) *Context {
 type Handler struct {
 	// Run is an entry function of the handler.
 	// The args are the arguments after the command name.
-	Run func(hs []Handler, i int, args Data)
+	// Returned error is used by Context.Run to decide on the exit code.
+	Run func(hs []Handler, i int, args Data) error
 
 	// Default means the handler must be executed if no arguments are
 	// received from user (in addition to when it is called explicitly).
 	// Only first default handler is used, others will be ignored.
 	Default bool
 
-	Name  string // Name of the handler, e.g. "new" or "generate stuff".
-	Usage string // Possible arguments of the command, e.g. "[input] [output]".
-	Info  string // One line description of the command.
-	Desc  string // Detailed description of what the command does.
+	Name    string   // Name of the handler, e.g. "new" or "generate stuff".
+	Aliases []string // Alternative names of the handler, e.g. "n" or "g s".
+	Usage   string   // Possible arguments of the command, e.g. "[input] [output]".
+	Info    string   // One line description of the command.
+	Desc    string   // Detailed description of what the command does.
 
 	Flags flag.FlagSet // Set of flags specific to the command.
 }
 
 // Run gets a list of arguments and either starts an entry function of the
 // requested subcommand (aka tool) or returns an error.
-func (c *Context) Run(args []string) error {
+// If there is no such subcommand, an executable named "goal-<word>[-<word>]"
+// is looked up in PATH and started instead.
+// Returned errors are of type *Error, their Code fields are ExitUsage if
+// the command or its flags are incorrect, ExitFailure if the command
+// has returned an error, and ExitInternal if it has panicked.
+func (c *Context) Run(args []string) (err error) {
+	// Treat panics of the subcommands as internal errors.
+	defer func() {
+		if v := recover(); v != nil {
+			err = &Error{
+				Code:  ExitInternal,
+				Err:   fmt.Errorf("%v", v),
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
 	// Start default handler's entry function if no arguments are received.
 	if len(args) == 0 {
 		if c.defaultH != nil {
-			c.list[*c.defaultH].Run(c.list, *c.defaultH, args)
-			return nil
+			return wrap(c.list[*c.defaultH].Run(c.list, *c.defaultH, args))
 		}
-		return errors.New("no command specified")
+		return UsageError("no command specified")
 	}
 
 	// Otherwise, iterating over all available handlers of subcommands (aka tools).
@@ -82,16 +103,68 @@ func (c *Context) Run(args []string) error {
 			// Parse flags if there are any.
 			err := c.list[i].Flags.Parse(lst)
 			if err != nil {
-				return err
+				return &Error{
+					Code: ExitUsage,
+					Err:  err,
+				}
 			}
 
 			// Start the entry function of the handler.
 			// Use h.Flags' non-flag values as arguments.
-			c.list[i].Run(c.list, i, c.list[i].Flags.Args())
-			return nil
+			return wrap(c.list[i].Run(c.list, i, c.list[i].Flags.Args()))
 		}
 	}
-	return fmt.Errorf(`unknown command "%s"`, strings.Join(args, commandWordSep))
+
+	// Try to find an external subcommand (plugin) in PATH.
+	// Its flags are not parsed, all arguments are passed as is.
+	hs := withPlugins(c.list)
+	for i := len(c.list); i < len(hs); i++ {
+		if lst, ok := hs[i].Requested(args); ok {
+			return wrap(hs[i].Run(hs, i, lst))
+		}
+	}
+	return UsageError(`unknown command "%s"%s`, strings.Join(args, commandWordSep), suggest(hs, args))
+}
+
+// Command returns a name of the subcommand (tool) that is requested
+// by args, e.g. "generate handlers", or empty string if there is no such
+// subcommand. Names of the external subcommands (plugins) are returned, too.
+func (c *Context) Command(args []string) string {
+	if len(args) == 0 {
+		if c.defaultH != nil {
+			return c.list[*c.defaultH].Name
+		}
+		return ""
+	}
+	for i := range c.list {
+		if _, ok := c.list[i].Requested(args); ok {
+			return c.list[i].Name
+		}
+	}
+
+	// PATH is only scanned if none of the built-in subcommands is requested.
+	ps := plugins(c.list)
+	for i := range ps {
+		if _, ok := ps[i].Requested(args); ok {
+			return ps[i].Name
+		}
+	}
+	return ""
+}
+
+// wrap gets an error returned by a handler and makes sure
+// it is of type *Error. Nil is returned as is.
+func wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	return &Error{
+		Code: ExitFailure,
+		Err:  err,
+	}
 }
 
 // Requested checks whether the handler is the one that is requested by user,
@@ -99,18 +172,33 @@ func (c *Context) Run(args []string) error {
 // It returns arguments (not including the handler name) and true in case
 // of success, and nil, false otherwise.
 func (h Handler) Requested(args []string) ([]string, bool) {
-	// Calculate the number of words in the handler's name.
+	if as, ok := requested(h.Name, args); ok {
+		return as, true
+	}
+	for i := range h.Aliases {
+		if as, ok := requested(h.Aliases[i], args); ok {
+			return as, true
+		}
+	}
+	return nil, false
+}
+
+// requested checks whether the name is a part of args.
+// It returns arguments (not including the name) and true in case
+// of success, and nil, false otherwise.
+func requested(name string, args []string) ([]string, bool) {
+	// Calculate the number of words in the name.
 	// It is equal to the number of spaces plus one.
-	num := strings.Count(h.Name, commandWordSep) + 1
+	num := strings.Count(name, commandWordSep) + 1
 
 	// If the number of arguments is less than the number of words
-	// in handler's name that means this is not the command user wants.
+	// in the name that means this is not the command user wants.
 	if len(args) < num {
 		return nil, false
 	}
 
-	// Make sure the handler's name is equal to the one user requested.
-	if h.Name != strings.Join(args[:num], commandWordSep) {
+	// Make sure the name is equal to the one user requested.
+	if name != strings.Join(args[:num], commandWordSep) {
 		return nil, false
 	}
 
@@ -1,6 +1,7 @@
 package tool
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -11,14 +12,16 @@ func TestRun_Default(t *testing.T) {
 	c := NewContext(
 		Handler{
 			Default: true,
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 		Handler{ // Second one must be ignored.
 			Default: true,
-			Run: func(h []Handler, i int, args Data) {
+			Run: func(h []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 	)
@@ -31,8 +34,9 @@ func TestRun_NoDefault(t *testing.T) {
 	count := 0
 	c := NewContext(
 		Handler{
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 	)
@@ -46,20 +50,26 @@ func TestRun_NotFound(t *testing.T) {
 	c := NewContext(
 		Handler{
 			Name: "run",
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 		Handler{
 			Name: "go generate",
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 	)
 	if err := c.Run([]string{"start --stuff xxx"}); count != 0 || err == nil {
 		t.Errorf(`Non-existent command requested. Expected "nil", got "%s".`, err)
 	}
+	exp := `unknown command "go generat", did you mean "go generate"?`
+	if err := c.Run([]string{"go", "generat"}); count != 0 || err == nil || err.Error() != exp {
+		t.Errorf(`Non-existent command requested. Expected "%s", got "%v".`, exp, err)
+	}
 }
 
 func TestRun(t *testing.T) {
@@ -67,18 +77,21 @@ func TestRun(t *testing.T) {
 	c := NewContext(
 		Handler{
 			Name: "run",
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
+				return nil
 			},
 		},
 		Handler{
 			Name: "go generate",
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
 				count++
+				return nil
 			},
 		},
 		Handler{
 			Name: "new",
-			Run: func(hs []Handler, i int, args Data) {
+			Run: func(hs []Handler, i int, args Data) error {
+				return nil
 			},
 		},
 	)
@@ -95,6 +108,75 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestRun_ExitCodes(t *testing.T) {
+	c := NewContext(
+		Handler{
+			Name: "ok",
+			Run: func(hs []Handler, i int, args Data) error {
+				return nil
+			},
+		},
+		Handler{
+			Name: "fail",
+			Run: func(hs []Handler, i int, args Data) error {
+				return errors.New("test error")
+			},
+		},
+		Handler{
+			Name: "usage",
+			Run: func(hs []Handler, i int, args Data) error {
+				return UsageError("incorrect argument %s", "x")
+			},
+		},
+		Handler{
+			Name: "panic",
+			Run: func(hs []Handler, i int, args Data) error {
+				panic("test panic")
+			},
+		},
+	)
+	for cmd, exp := range map[string]int{
+		"ok":                ExitOK,
+		"fail":              ExitFailure,
+		"usage":             ExitUsage,
+		"panic":             ExitInternal,
+		"unknown":           ExitUsage,
+		"ok --unknown-flag": ExitUsage,
+	} {
+		err := c.Run(strings.Split(cmd, commandWordSep))
+		if code := ExitCode(err); code != exp {
+			t.Errorf(`"%s": exit code %d expected, got %d ("%v").`, cmd, exp, code, err)
+		}
+	}
+
+	err := c.Run([]string{"panic"})
+	if e, ok := err.(*Error); !ok || len(e.Stack) == 0 || e.Error() != "test panic" {
+		t.Errorf(`Panic is expected to be returned with a stack trace, got %#v.`, err)
+	}
+}
+
+func TestContextCommand(t *testing.T) {
+	c := NewContext(testHandlers()...)
+	for cmd, exp := range map[string]string{
+		"":                        "",
+		"generate stuff --output": "generate stuff",
+		"new ./app":               "new",
+		"help new":                "help",
+		"generate":                "",
+		"unknown":                 "",
+	} {
+		if res := c.Command(strings.Fields(cmd)); res != exp {
+			t.Errorf(`"%s": expected command "%s", got "%s".`, cmd, exp, res)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode(errors.New("test")); code != ExitFailure {
+		t.Errorf(`Errors of unknown types are failures. Expected %d, got %d.`, ExitFailure, code)
+	}
+}
+
 func TestHandlerRequested(t *testing.T) {
 	ts := map[string]struct {
 		h    Handler
@@ -129,6 +211,22 @@ func TestHandlerRequested(t *testing.T) {
 			args: nil,
 			ok:   false,
 		},
+		"g s --something x": {
+			h: Handler{
+				Name:    "generate stuff",
+				Aliases: []string{"gen stuff", "g s"},
+			},
+			args: []string{"--something", "x"},
+			ok:   true,
+		},
+		"g": {
+			h: Handler{
+				Name:    "generate stuff",
+				Aliases: []string{"g s"},
+			},
+			args: nil,
+			ok:   false,
+		},
 	}
 	for cmd, res := range ts {
 		args := strings.Split(cmd, commandWordSep)
@@ -0,0 +1,211 @@
+// Package validation implements checks of request parameters.
+// It is used by the generated handlers for validation of action
+// parameters that have rules attached, e.g.:
+//
+//	// Index is an action with a validated parameter.
+//	//@validate page min=1 max=100
+//	func (c *App) Index(page int) http.Handler {
+//		...
+//	}
+//
+// or fields of structures that have "validate" tags:
+//
+//	type UserForm struct {
+//		Name string `form:"name" validate:"required max=64"`
+//	}
+//
+// Failures are stored in controller fields of Errors type
+// tagged with `bind:"errors"`.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind defines how the rules are applied to a value.
+type Kind int
+
+// Kinds of values that are supported.
+const (
+	Other  Kind = iota // Only "required" rule is applied.
+	Number             // "min" and "max" limit the value.
+	String             // "min" and "max" limit the number of characters.
+	List               // "min" and "max" limit the number of values.
+)
+
+// Names of the supported rules.
+const (
+	Required = "required"
+	Min      = "min"
+	Max      = "max"
+)
+
+// Rule is a parsed validation rule, e.g. "min=1".
+type Rule struct {
+	Name  string
+	Limit float64 // Argument of "min" and "max" rules.
+}
+
+// ParseRule parses a rule of "required", "min=N", or "max=N" format.
+func ParseRule(s string) (Rule, error) {
+	i := strings.Index(s, "=")
+	if i < 0 {
+		if s != Required {
+			return Rule{}, fmt.Errorf(`unknown validation rule "%s"`, s)
+		}
+		return Rule{Name: s}, nil
+	}
+	r := Rule{Name: s[:i]}
+	if r.Name != Min && r.Name != Max {
+		return Rule{}, fmt.Errorf(`unknown validation rule "%s"`, s)
+	}
+	n, err := strconv.ParseFloat(s[i+1:], 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf(`limit of validation rule "%s" must be a number`, s)
+	}
+	r.Limit = n
+	return r, nil
+}
+
+// Error is a failure of a single rule.
+type Error struct {
+	Key     string // Key of the value, e.g. "page".
+	Rule    string // Rule that has failed, e.g. "min=1".
+	Message string // Description of the failure, e.g. "must be at least 1".
+}
+
+// Error returns the key and description of the failure.
+func (e Error) Error() string {
+	return e.Key + " " + e.Message
+}
+
+// Errors is a list of validation failures. It implements http.Handler
+// interface, so it may be returned from actions.
+type Errors []Error
+
+// Error returns descriptions of all failures.
+func (es Errors) Error() string {
+	ss := make([]string, len(es))
+	for i := range es {
+		ss[i] = es[i].Error()
+	}
+	return strings.Join(ss, "; ")
+}
+
+// ServeHTTP writes 400 status and descriptions of the failures,
+// one per line.
+func (es Errors) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	for i := range es {
+		fmt.Fprintln(w, es[i].Error())
+	}
+}
+
+// Join concatenates lists of errors. If there are no errors,
+// nil is returned.
+func Join(ess ...Errors) (res Errors) {
+	for i := range ess {
+		res = append(res, ess[i]...)
+	}
+	return
+}
+
+// Check validates the value of the key using the rules and returns
+// the failures. Values that are missing are not checked
+// unless "required" rule is used. Incorrect rules are reported
+// as failures, too. A value of Number kind that cannot be parsed
+// is reported once no matter how many rules limit it.
+func Check(vs url.Values, k string, kind Kind, rules ...string) (es Errors) {
+	v := ""
+	if len(vs[k]) > 0 {
+		v = vs[k][len(vs[k])-1]
+	}
+	missing := v == "" && (kind != List || len(vs[k]) == 0)
+	var (
+		n        float64
+		unit     string
+		err      error
+		reported bool // Whether the error of measure is reported already.
+	)
+	if !missing {
+		n, unit, err = measure(kind, vs[k], v)
+	}
+	for _, s := range rules {
+		r, rerr := ParseRule(s)
+		if rerr != nil {
+			es = append(es, Error{Key: k, Rule: s, Message: rerr.Error()})
+			continue
+		}
+		if r.Name == Required {
+			if missing {
+				es = append(es, Error{Key: k, Rule: s, Message: "is required"})
+			}
+			continue
+		}
+		if missing || reported {
+			continue
+		}
+		if err != nil {
+			es = append(es, Error{Key: k, Rule: s, Message: err.Error()})
+			reported = true
+			continue
+		}
+		if msg, ok := check(r, kind, n, unit); !ok {
+			es = append(es, Error{Key: k, Rule: s, Message: msg})
+		}
+	}
+	return
+}
+
+// errNumber is an error that is returned if the value
+// of Number kind cannot be parsed.
+var errNumber = errors.New("must be a number")
+
+// measure returns the quantity "min" and "max" rules limit
+// and its unit. An error is returned if the value must be
+// a number but it cannot be parsed.
+func measure(kind Kind, vs []string, v string) (float64, string, error) {
+	switch kind {
+	case Number:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, "", errNumber
+		}
+		return f, "", nil
+	case String:
+		return float64(utf8.RuneCountInString(v)), " characters long", nil
+	case List:
+		return float64(len(vs)), " values", nil
+	}
+	return 0, "", nil
+}
+
+// check applies "min" or "max" rule to the quantity of the value.
+// If the value is valid, true is returned. Otherwise,
+// a description of the failure and false.
+func check(r Rule, kind Kind, n float64, unit string) (string, bool) {
+	if kind != Number && kind != String && kind != List {
+		return fmt.Sprintf(`does not support "%s" rule`, r.Name), false
+	}
+	limit := strconv.FormatFloat(r.Limit, 'f', -1, 64)
+	if r.Name == Min && n < r.Limit {
+		if kind == List {
+			return "must have at least " + limit + unit, false
+		}
+		return "must be at least " + limit + unit, false
+	}
+	if r.Name == Max && n > r.Limit {
+		if kind == List {
+			return "must have at most " + limit + unit, false
+		}
+		return "must be at most " + limit + unit, false
+	}
+	return "", true
+}
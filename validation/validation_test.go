@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	for s, exp := range map[string]Rule{
+		"required": {Name: Required},
+		"min=1":    {Name: Min, Limit: 1},
+		"max=2.5":  {Name: Max, Limit: 2.5},
+	} {
+		if r, err := ParseRule(s); err != nil || r != exp {
+			t.Errorf(`"%s": expected %#v, got %#v, %v.`, s, exp, r, err)
+		}
+	}
+	for _, s := range []string{"", "min", "max=x", "pattern=.*", "required=1"} {
+		if _, err := ParseRule(s); err == nil {
+			t.Errorf(`"%s": error expected.`, s)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	vs := url.Values{
+		"page":    {"0"},
+		"limit":   {"x"},
+		"name":    {"Джон"},
+		"tags[]":  {"a", "b", "c"},
+		"empty":   {""},
+		"correct": {"50"},
+	}
+	for i, v := range []struct {
+		key   string
+		kind  Kind
+		rules []string
+		exp   []string
+	}{
+		{"page", Number, []string{"min=1", "max=100"}, []string{"page must be at least 1"}},
+		{"correct", Number, []string{"required", "min=1", "max=100"}, nil},
+		{"limit", Number, []string{"max=100"}, []string{"limit must be a number"}},
+		{"limit", Number, []string{"min=1", "max=100"}, []string{"limit must be a number"}},
+		{"limit", Number, []string{"min=1", "unknown", "max=100"}, []string{"limit must be a number", `limit unknown validation rule "unknown"`}},
+		{"name", String, []string{"min=2", "max=3"}, []string{"name must be at most 3 characters long"}},
+		{"tags[]", List, []string{"max=2"}, []string{"tags[] must have at most 2 values"}},
+		{"missing", Number, []string{"min=1"}, nil},
+		{"missing", Other, []string{"required"}, []string{"missing is required"}},
+		{"empty", String, []string{"required", "min=1"}, []string{"empty is required"}},
+		{"page", Other, []string{"min=1"}, []string{`page does not support "min" rule`}},
+	} {
+		es := Check(vs, v.key, v.kind, v.rules...)
+		res := []string(nil)
+		for _, e := range es {
+			res = append(res, e.Error())
+		}
+		if !reflect.DeepEqual(res, v.exp) {
+			t.Errorf("Test %d: expected %#v, got %#v.", i, v.exp, res)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	es := Join(nil, Errors{{Key: "a", Message: "is required"}}, Errors{{Key: "b", Message: "must be a number"}})
+	if exp := "a is required; b must be a number"; es.Error() != exp {
+		t.Errorf(`Expected "%s", got "%s".`, exp, es.Error())
+	}
+	if Join(nil, Errors{}) != nil {
+		t.Errorf("Nil expected if there are no errors.")
+	}
+
+	w := httptest.NewRecorder()
+	es.ServeHTTP(w, nil)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "b must be a number\n") {
+		t.Errorf(`Unexpected response: %d "%s".`, w.Code, w.Body.String())
+	}
+}